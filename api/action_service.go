@@ -8,7 +8,6 @@ import (
 	"strconv"
 
 	"github.com/turbot/steampipe-plugin-bigfix/api/model"
-	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"resty.dev/v3"
 )
 
@@ -26,55 +25,89 @@ func NewActionService(client *Client) *ActionService {
 
 // List retrieves all actions
 func (as *ActionService) List(ctx context.Context) ([]model.Action, error) {
+	var actions []model.Action
+	err := as.ListFunc(ctx, func(action model.Action) bool {
+		actions = append(actions, action)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	as.client.logger.Debug("API response", "actions", actions)
+
+	return actions, nil
+}
+
+// ListFunc streams all actions to fn as they are decoded from the response, stopping as soon as fn returns false
+func (as *ActionService) ListFunc(ctx context.Context, fn func(model.Action) bool) error {
 	endpoint := "/api/actions"
 
 	// Perform the request with retry logic and limiter tag
-	resp, err := as.client.executeWithRetryDefaultWithLimiter(func() (*resty.Response, error) {
+	resp, err := as.client.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
 		return as.client.Resty.R().
+			SetContext(ctx).
 			SetHeader("Accept", "application/xml").
 			Get(as.client.BaseURL + ":" + strconv.Itoa(as.client.PortNumber) + endpoint)
 	}, "bigfix_action_list")
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch actions: %w", err)
+		return fmt.Errorf("failed to fetch actions: %w", err)
 	}
 
 	if resp.Body != nil {
 		defer resp.Body.Close()
 	}
 
-	// Read the response body
-	body, err := io.ReadAll(resp.Body)
+	// Decode the actions one at a time
+	return decodeXMLList(resp.Body, "Action", fn)
+}
+
+// Get retrieves a specific action detail
+func (as *ActionService) Get(ctx context.Context, actionID int) (*model.Action, error) {
+	return as.GetCached(ctx, actionID, "")
+}
+
+// GetCached retrieves a specific action detail like Get. When lastModified is set, as returned for the action
+// in list responses, the detail is served from the disk cache of the client if it did not change.
+func (as *ActionService) GetCached(ctx context.Context, actionID int, lastModified string) (*model.Action, error) {
+	endpoint := "/api/action/" + strconv.Itoa(actionID)
+
+	// Fetch the detail, from the disk cache when unchanged
+	body, err := as.client.getDetail(ctx, endpoint, lastModified, "bigfix_action_get")
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to fetch action %d: %w", actionID, err)
 	}
 
-	// Parse XML for actions response
-	var result model.ActionListResponse
+	// Parse XML for action detail response
+	var result model.ActionDetailResponse
 	if err := xml.Unmarshal(body, &result); err != nil {
 		return nil, fmt.Errorf("failed to parse XML response: %w", err)
 	}
 
-	// Actions don't have site information in the API response
+	// Convert to Action model
+	resourceURL := as.client.BaseURL + ":" + strconv.Itoa(as.client.PortNumber) + endpoint
+	action := result.ToAction(actionID, resourceURL)
 
-	plugin.Logger(ctx).Debug("API response actions:", result.Actions)
+	as.client.logger.Debug("API response", "action", action)
 
-	return result.Actions, nil
+	return action, nil
 }
 
-// Get retrieves a specific action detail
-func (as *ActionService) Get(ctx context.Context, actionID int) (*model.Action, error) {
-	endpoint := "/api/action/" + strconv.Itoa(actionID)
+// Status retrieves the per-computer results of a specific action
+func (as *ActionService) Status(ctx context.Context, actionID int) ([]model.ActionComputerStatus, error) {
+	endpoint := "/api/action/" + strconv.Itoa(actionID) + "/status"
 
 	// Perform the request with retry logic and limiter tag
-	resp, err := as.client.executeWithRetryDefaultWithLimiter(func() (*resty.Response, error) {
+	resp, err := as.client.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
 		return as.client.Resty.R().
+			SetContext(ctx).
 			SetHeader("Accept", "application/xml").
 			Get(as.client.BaseURL + ":" + strconv.Itoa(as.client.PortNumber) + endpoint)
-	}, "bigfix_action_get")
+	}, "bigfix_action_status")
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch action %d: %w", actionID, err)
+		return nil, fmt.Errorf("failed to fetch status for action %d: %w", actionID, err)
 	}
 
 	if resp.Body != nil {
@@ -87,17 +120,21 @@ func (as *ActionService) Get(ctx context.Context, actionID int) (*model.Action,
 		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	// Parse XML for action detail response
-	var result model.ActionDetailResponse
+	// Parse XML for action status response
+	var result model.ActionStatusResponse
 	if err := xml.Unmarshal(body, &result); err != nil {
 		return nil, fmt.Errorf("failed to parse XML response: %w", err)
 	}
 
-	// Convert to Action model
-	resourceURL := as.client.BaseURL + ":" + strconv.Itoa(as.client.PortNumber) + endpoint
-	action := result.Action.ToAction(actionID, resourceURL)
+	// Ensure the action ID is set from the URL parameter
+	result.ActionResults.ActionID = actionID
+	if result.ActionResults.Resource == "" {
+		result.ActionResults.Resource = as.client.BaseURL + ":" + strconv.Itoa(as.client.PortNumber) + endpoint
+	}
 
-	plugin.Logger(ctx).Debug("API response action:", action)
+	statuses := result.ActionResults.ToActionComputerStatuses()
 
-	return action, nil
+	as.client.logger.Debug("API response", "action_status", statuses)
+
+	return statuses, nil
 }
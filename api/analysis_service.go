@@ -4,12 +4,10 @@ import (
 	"context"
 	"encoding/xml"
 	"fmt"
-	"io"
 	"net/url"
 	"strconv"
 
 	"github.com/turbot/steampipe-plugin-bigfix/api/model"
-	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"resty.dev/v3"
 )
 
@@ -27,6 +25,22 @@ func NewAnalysisService(client *Client) *AnalysisService {
 
 // List retrieves all analyses for a specific site
 func (as *AnalysisService) List(ctx context.Context, siteName string, siteType string) ([]model.Analysis, error) {
+	var analyses []model.Analysis
+	err := as.ListFunc(ctx, siteName, siteType, func(analysis model.Analysis) bool {
+		analyses = append(analyses, analysis)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	as.client.logger.Debug("API response", "analyses", analyses)
+
+	return analyses, nil
+}
+
+// ListFunc streams the analyses of the site to fn as they are decoded from the response, stopping as soon as fn returns false
+func (as *AnalysisService) ListFunc(ctx context.Context, siteName string, siteType string, fn func(model.Analysis) bool) error {
 	var endpoint string
 
 	switch siteType {
@@ -38,50 +52,77 @@ func (as *AnalysisService) List(ctx context.Context, siteName string, siteType s
 		endpoint = "/api/analyses/master"
 	case "action":
 		endpoint = "/api/analyses/action/" + url.PathEscape(siteName)
+	case "custom":
+		endpoint = "/api/analyses/custom/" + url.PathEscape(siteName)
 	default:
-		return nil, fmt.Errorf("invalid site type: %s. Must be one of: external, operator, master, action", siteType)
+		return fmt.Errorf("invalid site type: %s. Must be one of: external, operator, master, action, custom", siteType)
 	}
 
 	// Perform the request with retry logic and limiter tag
-	resp, err := as.client.executeWithRetryDefaultWithLimiter(func() (*resty.Response, error) {
+	resp, err := as.client.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
 		return as.client.Resty.R().
+			SetContext(ctx).
 			SetHeader("Accept", "application/xml").
 			Get(as.client.BaseURL + ":" + strconv.Itoa(as.client.PortNumber) + endpoint)
 	}, "bigfix_analysis_list")
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch analyses for site %s (%s): %w", siteName, siteType, err)
+		return fmt.Errorf("failed to fetch analyses for site %s (%s): %w", siteName, siteType, err)
 	}
 
 	if resp.Body != nil {
 		defer resp.Body.Close()
 	}
 
-	// Read the response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
+	// Decode the analyses one at a time, setting the site information on each
+	return decodeXMLList(resp.Body, "Analysis", func(analysis model.Analysis) bool {
+		analysis.SiteName = siteName
+		analysis.SiteType = siteType
+		return fn(analysis)
+	})
+}
 
-	// Parse XML for analyses response
-	var result model.AnalysisListResponse
-	if err := xml.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse XML response: %w", err)
+// ListDetails retrieves the title, category and source release date of all the analyses of a site
+// with a single session relevance query, keyed by analysis ID. This is much faster than calling Get
+// for each analysis of large sites. The relevance is left out, as session relevance only returns the
+// clauses of an analysis combined into a single expression.
+func (as *AnalysisService) ListDetails(ctx context.Context, siteName string, siteType string) (map[int]model.Analysis, error) {
+	rows, err := as.client.querySiteContent(ctx, siteName, siteType, analysisFlag, []string{
+		"name of it",
+		"category of it",
+		"source release date of it as string",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch analysis details for site %s (%s): %w", siteName, siteType, err)
 	}
 
-	// Set site information for each analysis
-	for i := range result.Analyses {
-		result.Analyses[i].SiteName = siteName
-		result.Analyses[i].SiteType = siteType
+	analyses := make(map[int]model.Analysis, len(rows))
+	for id, row := range rows {
+		analyses[id] = model.Analysis{
+			ID:                id,
+			Name:              row[0],
+			SiteName:          siteName,
+			SiteType:          siteType,
+			Title:             row[0],
+			Category:          row[1],
+			SourceReleaseDate: relevanceDate(row[2]),
+			DetailsFetched:    true,
+		}
 	}
 
-	plugin.Logger(ctx).Debug("API response analyses:", result.Analyses)
+	as.client.logger.Debug("API response", "analysis_details", len(analyses))
 
-	return result.Analyses, nil
+	return analyses, nil
 }
 
 // Get retrieves a specific analysis detail
 func (as *AnalysisService) Get(ctx context.Context, siteName string, siteType string, analysisID int) (*model.Analysis, error) {
+	return as.GetCached(ctx, siteName, siteType, analysisID, "")
+}
+
+// GetCached retrieves a specific analysis detail like Get. When lastModified is set, as returned for the analysis
+// in list responses, the detail is served from the disk cache of the client if it did not change.
+func (as *AnalysisService) GetCached(ctx context.Context, siteName string, siteType string, analysisID int, lastModified string) (*model.Analysis, error) {
 	var endpoint string
 
 	switch siteType {
@@ -93,31 +134,18 @@ func (as *AnalysisService) Get(ctx context.Context, siteName string, siteType st
 		endpoint = "/api/analysis/master/" + strconv.Itoa(analysisID)
 	case "action":
 		endpoint = "/api/analysis/action/" + url.PathEscape(siteName) + "/" + strconv.Itoa(analysisID)
+	case "custom":
+		endpoint = "/api/analysis/custom/" + url.PathEscape(siteName) + "/" + strconv.Itoa(analysisID)
 	default:
-		return nil, fmt.Errorf("invalid site type: %s. Must be one of: external, operator, master, action", siteType)
+		return nil, fmt.Errorf("invalid site type: %s. Must be one of: external, operator, master, action, custom", siteType)
 	}
 
-	// Perform the request with retry logic and limiter tag
-	resp, err := as.client.executeWithRetryDefaultWithLimiter(func() (*resty.Response, error) {
-		return as.client.Resty.R().
-			SetHeader("Accept", "application/xml").
-			Get(as.client.BaseURL + ":" + strconv.Itoa(as.client.PortNumber) + endpoint)
-	}, "bigfix_analysis_get")
-
+	// Fetch the detail, from the disk cache when unchanged
+	body, err := as.client.getDetail(ctx, endpoint, lastModified, "bigfix_analysis_get")
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch analysis %d for site %s (%s): %w", analysisID, siteName, siteType, err)
 	}
 
-	if resp.Body != nil {
-		defer resp.Body.Close()
-	}
-
-	// Read the response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
 	// Parse XML for analysis detail response
 	var result model.AnalysisDetailResponse
 	if err := xml.Unmarshal(body, &result); err != nil {
@@ -128,7 +156,7 @@ func (as *AnalysisService) Get(ctx context.Context, siteName string, siteType st
 	resourceURL := as.client.BaseURL + ":" + strconv.Itoa(as.client.PortNumber) + endpoint
 	analysis := result.Analysis.ToAnalysis(analysisID, resourceURL, siteName, siteType)
 
-	plugin.Logger(ctx).Debug("API response analysis:", analysis)
+	as.client.logger.Debug("API response", "analysis", analysis)
 
 	return analysis, nil
 }
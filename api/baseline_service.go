@@ -0,0 +1,234 @@
+package api
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+
+	"github.com/turbot/steampipe-plugin-bigfix/api/model"
+	"resty.dev/v3"
+)
+
+// BaselineService encapsulates the API logic for baseline-related operations
+type BaselineService struct {
+	client *Client
+}
+
+// NewBaselineService creates a new BaselineService
+func NewBaselineService(client *Client) *BaselineService {
+	return &BaselineService{
+		client: client,
+	}
+}
+
+// List retrieves all baselines for a specific site
+func (bs *BaselineService) List(ctx context.Context, siteName string, siteType string) ([]model.Baseline, error) {
+	var baselines []model.Baseline
+	err := bs.ListFunc(ctx, siteName, siteType, func(baseline model.Baseline) bool {
+		baselines = append(baselines, baseline)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bs.client.logger.Debug("API response", "baselines", baselines)
+
+	return baselines, nil
+}
+
+// ListFunc streams the baselines of the site to fn as they are decoded from the response, stopping as soon as fn returns false
+func (bs *BaselineService) ListFunc(ctx context.Context, siteName string, siteType string, fn func(model.Baseline) bool) error {
+	var endpoint string
+
+	switch siteType {
+	case "external":
+		endpoint = "/api/baselines/external/" + url.PathEscape(siteName)
+	case "operator":
+		endpoint = "/api/baselines/operator/" + url.PathEscape(siteName)
+	case "master":
+		endpoint = "/api/baselines/master"
+	case "action":
+		endpoint = "/api/baselines/action/" + url.PathEscape(siteName)
+	case "custom":
+		endpoint = "/api/baselines/custom/" + url.PathEscape(siteName)
+	default:
+		return fmt.Errorf("invalid site type: %s. Must be one of: external, operator, master, action, custom", siteType)
+	}
+
+	// Perform the request with retry logic and limiter tag
+	resp, err := bs.client.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
+		return bs.client.Resty.R().
+			SetContext(ctx).
+			SetHeader("Accept", "application/xml").
+			Get(bs.client.BaseURL + ":" + strconv.Itoa(bs.client.PortNumber) + endpoint)
+	}, "bigfix_baseline_list")
+
+	if err != nil {
+		return fmt.Errorf("failed to fetch baselines for site %s (%s): %w", siteName, siteType, err)
+	}
+
+	if resp.Body != nil {
+		defer resp.Body.Close()
+	}
+
+	// Decode the baselines one at a time, setting the site information on each
+	return decodeXMLList(resp.Body, "Baseline", func(baseline model.Baseline) bool {
+		baseline.SiteName = siteName
+		baseline.SiteType = siteType
+		return fn(baseline)
+	})
+}
+
+// Get retrieves a specific baseline detail
+func (bs *BaselineService) Get(ctx context.Context, siteName string, siteType string, baselineID int) (*model.Baseline, error) {
+	var endpoint string
+
+	switch siteType {
+	case "external":
+		endpoint = "/api/baseline/external/" + url.PathEscape(siteName) + "/" + strconv.Itoa(baselineID)
+	case "operator":
+		endpoint = "/api/baseline/operator/" + url.PathEscape(siteName) + "/" + strconv.Itoa(baselineID)
+	case "master":
+		endpoint = "/api/baseline/master/" + strconv.Itoa(baselineID)
+	case "action":
+		endpoint = "/api/baseline/action/" + url.PathEscape(siteName) + "/" + strconv.Itoa(baselineID)
+	case "custom":
+		endpoint = "/api/baseline/custom/" + url.PathEscape(siteName) + "/" + strconv.Itoa(baselineID)
+	default:
+		return nil, fmt.Errorf("invalid site type: %s. Must be one of: external, operator, master, action, custom", siteType)
+	}
+
+	// Perform the request with retry logic and limiter tag
+	resp, err := bs.client.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
+		return bs.client.Resty.R().
+			SetContext(ctx).
+			SetHeader("Accept", "application/xml").
+			Get(bs.client.BaseURL + ":" + strconv.Itoa(bs.client.PortNumber) + endpoint)
+	}, "bigfix_baseline_get")
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch baseline %d for site %s (%s): %w", baselineID, siteName, siteType, err)
+	}
+
+	if resp.Body != nil {
+		defer resp.Body.Close()
+	}
+
+	// Read the response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Parse XML for baseline detail response
+	var result model.BaselineDetailResponse
+	if err := xml.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse XML response: %w", err)
+	}
+
+	// Convert to Baseline model
+	resourceURL := bs.client.BaseURL + ":" + strconv.Itoa(bs.client.PortNumber) + endpoint
+	baseline := result.Baseline.ToBaseline(baselineID, resourceURL, siteName, siteType)
+
+	bs.client.logger.Debug("API response", "baseline", baseline)
+
+	return baseline, nil
+}
+
+// SourceSites returns the sites keyed by gather URL, which is how baseline components reference their
+// source site. It is listed once and passed to SyncComponents for every baseline.
+func (bs *BaselineService) SourceSites(ctx context.Context) (map[string]model.Site, error) {
+	sites := map[string]model.Site{}
+	err := bs.client.Site.ListFunc(ctx, func(site model.Site) bool {
+		if site.GatherURL != "" {
+			sites[site.GatherURL] = site
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list baseline source sites: %w", err)
+	}
+
+	return sites, nil
+}
+
+// SyncComponents sets IsSynced on each component of the baseline by comparing it with the current action of
+// its source fixlet or task, found in sites, as returned by SourceSites, through the gather URL of its source
+// site. Components whose source site or content cannot be found or read, e.g. because the operator has no
+// access to the source site, are left with an unknown sync status.
+func (bs *BaselineService) SyncComponents(ctx context.Context, baseline *model.Baseline, sites map[string]model.Site) {
+	// Components of the same source share the fetched actions, nil when the source was not found
+	sources := map[string][]model.FixletAction{}
+	for i := range baseline.Components {
+		component := &baseline.Components[i]
+
+		site, ok := sites[component.SourceSiteURL]
+		if !ok {
+			continue
+		}
+		sourceID, err := strconv.Atoi(component.SourceID)
+		if err != nil {
+			continue
+		}
+
+		key := component.SourceSiteURL + "/" + component.SourceID
+		actions, ok := sources[key]
+		if !ok {
+			actions, err = bs.getSourceActions(ctx, site.Name, site.Type, sourceID)
+			if err != nil {
+				// The sync status of the other components can still be known
+				if ctx.Err() != nil {
+					return
+				}
+				if !IsNotFound(err) {
+					bs.client.logger.Warn("Failed to get baseline component source", "baseline", baseline.ID, "error", err)
+				}
+				actions = nil
+			}
+			sources[key] = actions
+		}
+		if actions == nil {
+			continue
+		}
+
+		synced := component.IsSyncedWith(actions)
+		component.IsSynced = &synced
+	}
+}
+
+// getSourceActions retrieves the actions of the fixlet or task a baseline component was copied from
+func (bs *BaselineService) getSourceActions(ctx context.Context, siteName string, siteType string, sourceID int) ([]model.FixletAction, error) {
+	var endpoint string
+
+	switch siteType {
+	case "external":
+		endpoint = "/api/fixlet/external/" + url.PathEscape(siteName) + "/" + strconv.Itoa(sourceID)
+	case "operator":
+		endpoint = "/api/fixlet/operator/" + url.PathEscape(siteName) + "/" + strconv.Itoa(sourceID)
+	case "master":
+		endpoint = "/api/fixlet/master/" + strconv.Itoa(sourceID)
+	case "action":
+		endpoint = "/api/fixlet/action/" + url.PathEscape(siteName) + "/" + strconv.Itoa(sourceID)
+	case "custom":
+		endpoint = "/api/fixlet/custom/" + url.PathEscape(siteName) + "/" + strconv.Itoa(sourceID)
+	default:
+		return nil, fmt.Errorf("invalid site type: %s. Must be one of: external, operator, master, action, custom", siteType)
+	}
+
+	body, err := bs.client.getDetail(ctx, endpoint, "", "bigfix_baseline_source_get")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch source %d of site %s (%s): %w", sourceID, siteName, siteType, err)
+	}
+
+	// Parse XML for the source fixlet or task
+	var result model.BaselineSourceResponse
+	if err := xml.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse XML response: %w", err)
+	}
+
+	return result.Actions(), nil
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-bigfix/api/model"
+)
+
+func TestBaselineSyncComponents(t *testing.T) {
+	var sourceRequests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/sites", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<BESAPI>
+			<ExternalSite Resource="r"><Name>BES Support</Name><GatherURL>http://sync.bigfix.com/bessupport</GatherURL></ExternalSite>
+			<CustomSite Resource="r"><Name>Patches</Name><GatherURL>http://bigfix:52311/cgi-bin/bfgather.exe/CustomSite_Patches</GatherURL></CustomSite>
+		</BESAPI>`))
+	})
+	mux.HandleFunc("/api/fixlet/external/BES%20Support/1", func(w http.ResponseWriter, r *http.Request) {
+		sourceRequests.Add(1)
+		w.Write([]byte(`<BES><Fixlet><Title>Fixlet 1</Title>
+			<DefaultAction ID="Action1"><ActionScript MIMEType="application/x-Fixlet-Windows-Shell">run "a.exe"</ActionScript></DefaultAction>
+			<Action ID="Action2"><ActionScript MIMEType="application/x-Fixlet-Windows-Shell">run "b.exe"</ActionScript></Action>
+		</Fixlet></BES>`))
+	})
+	mux.HandleFunc("/api/fixlet/custom/Patches/2", func(w http.ResponseWriter, r *http.Request) {
+		sourceRequests.Add(1)
+		w.Write([]byte(`<BES><Task><Title>Task 2</Title>
+			<DefaultAction ID="Action1"><ActionScript>run "new.exe"</ActionScript></DefaultAction>
+		</Task></BES>`))
+	})
+	mux.HandleFunc("/api/fixlet/custom/Patches/3", func(w http.ResponseWriter, r *http.Request) {
+		sourceRequests.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/api/fixlet/custom/Patches/4", func(w http.ResponseWriter, r *http.Request) {
+		sourceRequests.Add(1)
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	client, _ := newTestClient(t, mux)
+
+	baseline := &model.Baseline{
+		ID: 10,
+		Components: []model.BaselineComponent{
+			{Name: "synced default action", SourceSiteURL: "http://sync.bigfix.com/bessupport", SourceID: "1", ActionName: "Action1", ActionScript: "run \"a.exe\"\r\n"},
+			{Name: "synced other action", SourceSiteURL: "http://sync.bigfix.com/bessupport", SourceID: "1", ActionName: "Action2", ActionScript: `run "b.exe"`},
+			{Name: "changed task", SourceSiteURL: "http://bigfix:52311/cgi-bin/bfgather.exe/CustomSite_Patches", SourceID: "2", ActionName: "Action1", ActionScript: `run "old.exe"`},
+			{Name: "deleted source", SourceSiteURL: "http://bigfix:52311/cgi-bin/bfgather.exe/CustomSite_Patches", SourceID: "3", ActionName: "Action1"},
+			{Name: "forbidden source", SourceSiteURL: "http://bigfix:52311/cgi-bin/bfgather.exe/CustomSite_Patches", SourceID: "4", ActionName: "Action1"},
+			{Name: "unknown site", SourceSiteURL: "http://sync.bigfix.com/removed", SourceID: "1", ActionName: "Action1"},
+			{Name: "synced after failures", SourceSiteURL: "http://sync.bigfix.com/bessupport", SourceID: "1", ActionName: "Action2", ActionScript: `run "b.exe"`},
+		},
+	}
+
+	sites, err := client.Baseline.SourceSites(context.Background())
+	if err != nil {
+		t.Fatalf("source sites: %v", err)
+	}
+	if len(sites) != 2 {
+		t.Fatalf("got %d source sites, want 2", len(sites))
+	}
+
+	client.Baseline.SyncComponents(context.Background(), baseline, sites)
+
+	want := []*bool{ptr(true), ptr(true), ptr(false), nil, nil, nil, ptr(true)}
+	for i, component := range baseline.Components {
+		switch {
+		case want[i] == nil && component.IsSynced != nil:
+			t.Errorf("%s: got synced %v, want unknown", component.Name, *component.IsSynced)
+		case want[i] != nil && (component.IsSynced == nil || *component.IsSynced != *want[i]):
+			t.Errorf("%s: got synced %v, want %v", component.Name, component.IsSynced, *want[i])
+		}
+	}
+
+	// Each source is fetched once, even when several components share it
+	if got := sourceRequests.Load(); got != 4 {
+		t.Errorf("got %d source requests, want 4", got)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
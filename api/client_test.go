@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// connTracker records the client connections of requests to a test server
+type connTracker struct {
+	mu    sync.Mutex
+	conns map[string]bool
+}
+
+func (ct *connTracker) track(r *http.Request) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	if ct.conns == nil {
+		ct.conns = map[string]bool{}
+	}
+	ct.conns[r.RemoteAddr] = true
+}
+
+func (ct *connTracker) count() int {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	return len(ct.conns)
+}
+
+func TestExecuteWithRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		wantErr      bool
+		wantStatus   int
+		wantAttempts int32
+	}{
+		{name: "success", statuses: []int{200}, wantAttempts: 1},
+		{name: "server errors then success", statuses: []int{503, 500, 200}, wantAttempts: 3},
+		{name: "rate limited then success", statuses: []int{429, 200}, wantAttempts: 2},
+		{name: "retries exhausted", statuses: []int{502, 502, 502, 200}, wantErr: true, wantStatus: 502, wantAttempts: 3},
+		{name: "not found is not retried", statuses: []int{404, 200}, wantErr: true, wantStatus: 404, wantAttempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			var conns connTracker
+			client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				conns.track(r)
+				n := attempts.Add(1)
+				// Resty leaves the body of XML responses unread for the caller to stream
+				w.Header().Set("Content-Type", "application/xml")
+				w.WriteHeader(tt.statuses[n-1])
+				w.Write([]byte("<BESAPI>" + strings.Repeat("x", 1024) + "</BESAPI>"))
+			}))
+
+			resp, err := client.testGet(context.Background(), "/api/test")
+			if tt.wantErr {
+				if StatusCode(err) != tt.wantStatus {
+					t.Errorf("got error %v, want status %d", err, tt.wantStatus)
+				}
+				var retryErr *RetryError
+				if tt.wantAttempts > 1 && !errors.As(err, &retryErr) {
+					t.Errorf("got error %v, want a RetryError", err)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("request: %v", err)
+				}
+				resp.Body.Close()
+			}
+
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("got %d attempts, want %d", got, tt.wantAttempts)
+			}
+
+			// The bodies of retried responses are released, so every attempt reuses the same connection
+			if got := conns.count(); got != 1 {
+				t.Errorf("got %d connections, want 1", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package api
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+
+	"github.com/turbot/steampipe-plugin-bigfix/api/model"
+	"resty.dev/v3"
+)
+
+// ComputerGroupService encapsulates the API logic for computer group-related operations
+type ComputerGroupService struct {
+	client *Client
+}
+
+// NewComputerGroupService creates a new ComputerGroupService
+func NewComputerGroupService(client *Client) *ComputerGroupService {
+	return &ComputerGroupService{
+		client: client,
+	}
+}
+
+// List retrieves all computer groups for a specific site
+func (cgs *ComputerGroupService) List(ctx context.Context, siteName string, siteType string) ([]model.ComputerGroup, error) {
+	var groups []model.ComputerGroup
+	err := cgs.ListFunc(ctx, siteName, siteType, func(group model.ComputerGroup) bool {
+		groups = append(groups, group)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cgs.client.logger.Debug("API response", "computer_groups", groups)
+
+	return groups, nil
+}
+
+// ListFunc streams the computer groups of the site to fn as they are decoded from the response, stopping as soon as fn returns false
+func (cgs *ComputerGroupService) ListFunc(ctx context.Context, siteName string, siteType string, fn func(model.ComputerGroup) bool) error {
+	var endpoint string
+
+	switch siteType {
+	case "external":
+		endpoint = "/api/computergroups/external/" + url.PathEscape(siteName)
+	case "operator":
+		endpoint = "/api/computergroups/operator/" + url.PathEscape(siteName)
+	case "master", "action":
+		endpoint = "/api/computergroups/master"
+	case "custom":
+		endpoint = "/api/computergroups/custom/" + url.PathEscape(siteName)
+	default:
+		return fmt.Errorf("invalid site type: %s. Must be one of: external, operator, master, action, custom", siteType)
+	}
+
+	// Perform the request with retry logic and limiter tag
+	resp, err := cgs.client.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
+		return cgs.client.Resty.R().
+			SetContext(ctx).
+			SetHeader("Accept", "application/xml").
+			Get(cgs.client.BaseURL + ":" + strconv.Itoa(cgs.client.PortNumber) + endpoint)
+	}, "bigfix_computer_group_list")
+
+	if err != nil {
+		return fmt.Errorf("failed to fetch computer groups for site %s (%s): %w", siteName, siteType, err)
+	}
+
+	if resp.Body != nil {
+		defer resp.Body.Close()
+	}
+
+	// Decode the computer groups one at a time, setting the site information on each
+	return decodeXMLList(resp.Body, "ComputerGroup", func(group model.ComputerGroup) bool {
+		group.SiteName = siteName
+		group.SiteType = siteType
+		return fn(group)
+	})
+}
+
+// Get retrieves a specific computer group detail
+func (cgs *ComputerGroupService) Get(ctx context.Context, siteName string, siteType string, groupID int) (*model.ComputerGroup, error) {
+	var endpoint string
+
+	switch siteType {
+	case "external":
+		endpoint = "/api/computergroup/external/" + url.PathEscape(siteName) + "/" + strconv.Itoa(groupID)
+	case "operator":
+		endpoint = "/api/computergroup/operator/" + url.PathEscape(siteName) + "/" + strconv.Itoa(groupID)
+	case "master", "action":
+		endpoint = "/api/computergroup/master/" + strconv.Itoa(groupID)
+	case "custom":
+		endpoint = "/api/computergroup/custom/" + url.PathEscape(siteName) + "/" + strconv.Itoa(groupID)
+	default:
+		return nil, fmt.Errorf("invalid site type: %s. Must be one of: external, operator, master, action, custom", siteType)
+	}
+
+	// Perform the request with retry logic and limiter tag
+	resp, err := cgs.client.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
+		return cgs.client.Resty.R().
+			SetContext(ctx).
+			SetHeader("Accept", "application/xml").
+			Get(cgs.client.BaseURL + ":" + strconv.Itoa(cgs.client.PortNumber) + endpoint)
+	}, "bigfix_computer_group_get")
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch computer group %d for site %s (%s): %w", groupID, siteName, siteType, err)
+	}
+
+	if resp.Body != nil {
+		defer resp.Body.Close()
+	}
+
+	// Read the response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Parse XML for computer group detail response
+	var result model.ComputerGroupDetailResponse
+	if err := xml.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse XML response: %w", err)
+	}
+
+	// Convert to ComputerGroup model
+	resourceURL := cgs.client.BaseURL + ":" + strconv.Itoa(cgs.client.PortNumber) + endpoint
+	group := result.ToComputerGroup(groupID, resourceURL, siteName, siteType)
+
+	cgs.client.logger.Debug("API response", "computer_group", group)
+
+	return group, nil
+}
+
+// ListMembers retrieves the computers that are members of a specific computer group
+func (cgs *ComputerGroupService) ListMembers(ctx context.Context, siteName string, siteType string, groupID int) ([]model.ComputerGroupMember, error) {
+	var members []model.ComputerGroupMember
+	err := cgs.ListMembersFunc(ctx, siteName, siteType, groupID, func(member model.ComputerGroupMember) bool {
+		members = append(members, member)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cgs.client.logger.Debug("API response", "computer_group_members", members)
+
+	return members, nil
+}
+
+// ListMembersFunc streams the member computers of a computer group to fn as they are decoded from the response, stopping as soon as fn returns false
+func (cgs *ComputerGroupService) ListMembersFunc(ctx context.Context, siteName string, siteType string, groupID int, fn func(model.ComputerGroupMember) bool) error {
+	var endpoint string
+
+	switch siteType {
+	case "external":
+		endpoint = "/api/computergroup/external/" + url.PathEscape(siteName) + "/" + strconv.Itoa(groupID) + "/computers"
+	case "operator":
+		endpoint = "/api/computergroup/operator/" + url.PathEscape(siteName) + "/" + strconv.Itoa(groupID) + "/computers"
+	case "master", "action":
+		endpoint = "/api/computergroup/master/" + strconv.Itoa(groupID) + "/computers"
+	case "custom":
+		endpoint = "/api/computergroup/custom/" + url.PathEscape(siteName) + "/" + strconv.Itoa(groupID) + "/computers"
+	default:
+		return fmt.Errorf("invalid site type: %s. Must be one of: external, operator, master, action, custom", siteType)
+	}
+
+	// Perform the request with retry logic and limiter tag
+	resp, err := cgs.client.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
+		return cgs.client.Resty.R().
+			SetContext(ctx).
+			SetHeader("Accept", "application/xml").
+			Get(cgs.client.BaseURL + ":" + strconv.Itoa(cgs.client.PortNumber) + endpoint)
+	}, "bigfix_computer_group_member_list")
+
+	if err != nil {
+		return fmt.Errorf("failed to fetch members of computer group %d for site %s (%s): %w", groupID, siteName, siteType, err)
+	}
+
+	if resp.Body != nil {
+		defer resp.Body.Close()
+	}
+
+	// Decode the member computers one at a time, converting each to a ComputerGroupMember model
+	return decodeXMLElements(resp.Body, map[string]elementHandler{
+		"Computer": func(decoder *xml.Decoder, start xml.StartElement) error {
+			var computerXML model.ComputerListXML
+			if err := decoder.DecodeElement(&computerXML, &start); err != nil {
+				return fmt.Errorf("failed to parse XML response: %w", err)
+			}
+
+			computer, err := computerXML.ToComputer()
+			if err != nil {
+				return fmt.Errorf("failed to convert computer XML to model: %w", err)
+			}
+
+			member := model.ComputerGroupMember{
+				SiteName:       siteName,
+				SiteType:       siteType,
+				GroupID:        groupID,
+				ComputerID:     computer.ID,
+				Resource:       computer.Resource,
+				LastReportTime: computer.LastReportTime,
+			}
+			if !fn(member) {
+				return errStopDecoding
+			}
+			return nil
+		},
+	})
+}
@@ -7,12 +7,19 @@ import (
 	"io"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/turbot/steampipe-plugin-bigfix/api/model"
-	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"resty.dev/v3"
 )
 
+// ComputerIPAddressRelevance evaluates to the first IP address of a computer, returned as IPAddress
+// by ListWhereFunc like the list of computers returns a single address
+const ComputerIPAddressRelevance = `tuple string item 0 of concatenation ", " of (ip addresses of it as string)`
+
+// computerListFields are the fields always returned when listing computers
+const computerListFields = "ID,Name,OS,LastReportTime,CPU,IPAddress"
+
 // ComputerService handles computer-related API operations
 type ComputerService struct {
 	client *Client
@@ -24,46 +31,159 @@ func NewComputerService(client *Client) *ComputerService {
 }
 
 // List retrieves a list of computers from the BigFix API
-func (cs *ComputerService) List() ([]model.Computer, error) {
+func (cs *ComputerService) List(ctx context.Context) ([]model.Computer, error) {
+	var computers []model.Computer
+	err := cs.ListFunc(ctx, func(computer model.Computer) bool {
+		computers = append(computers, computer)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return computers, nil
+}
+
+// ListFunc streams the computers to fn as they are decoded from the response, stopping as soon as fn returns false
+func (cs *ComputerService) ListFunc(ctx context.Context, fn func(model.Computer) bool) error {
+	return cs.ListWithPropertiesFunc(ctx, nil, fn)
+}
+
+// ListWithPropertiesFunc streams the computers to fn like ListFunc, additionally retrieving the values of the
+// given retrieved properties in the same request, so they do not have to be fetched with Get for each computer.
+// The values are parsed into the fields of the computers and available through Computer.PropertyValues.
+// Property names must be listable, see IsListableProperty.
+func (cs *ComputerService) ListWithPropertiesFunc(ctx context.Context, propertyNames []string, fn func(model.Computer) bool) error {
+	fields := computerListFields
+	if len(propertyNames) > 0 {
+		for _, name := range propertyNames {
+			if !IsListableProperty(name) {
+				return fmt.Errorf("property %q cannot be requested when listing computers", name)
+			}
+		}
+		fields += ",Property<Name=" + strings.Join(propertyNames, ",") + ">"
+	}
+
 	// Build the endpoint URL with filtered fields
 	params := url.Values{}
-	params.Add("fields", "ID,Name,OS,LastReportTime,CPU,IPAddress")
+	params.Add("fields", fields)
 	endpoint := "/api/computers?" + params.Encode()
 
 	// Perform the request with retry logic and limiter tag
-	resp, err := cs.client.executeWithRetryDefaultWithLimiter(func() (*resty.Response, error) {
+	resp, err := cs.client.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
 		return cs.client.Resty.R().
+			SetContext(ctx).
 			SetHeader("Accept", "application/xml").
 			Get(cs.client.BaseURL + ":" + strconv.Itoa(cs.client.PortNumber) + endpoint)
 	}, "bigfix_computer_list")
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch computers: %w", err)
+		return fmt.Errorf("failed to fetch computers: %w", err)
 	}
 
-	// Read the response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+	if resp.Body != nil {
+		defer resp.Body.Close()
 	}
 
-	// Parse the XML response
-	var result model.BESAPI
-	if err := xml.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse XML response: %w", err)
+	// Decode the computers one at a time, converting each to a Computer model
+	return decodeXMLElements(resp.Body, map[string]elementHandler{
+		"Computer": func(decoder *xml.Decoder, start xml.StartElement) error {
+			var computerXML model.ComputerListXML
+			if err := decoder.DecodeElement(&computerXML, &start); err != nil {
+				return fmt.Errorf("failed to parse XML response: %w", err)
+			}
+
+			computer, err := computerXML.ToComputer()
+			if err != nil {
+				return fmt.Errorf("failed to convert computer XML to model: %w", err)
+			}
+
+			if !fn(*computer) {
+				return errStopDecoding
+			}
+			return nil
+		},
+	})
+}
+
+// ListWhereFunc streams the computers matching a session relevance condition to fn, e.g.
+// `operating system of it starts with "Win"`, stopping as soon as fn returns false.
+// The computers are filtered by the server through /api/query and returned with the same fields as
+// ListWithPropertiesFunc, including the values of the given retrieved properties.
+// Multiple values of a property are returned separated by newlines by the server and split again.
+func (cs *ComputerService) ListWhereFunc(ctx context.Context, condition string, propertyNames []string, fn func(model.Computer) bool) error {
+	// Tuple items which cannot be evaluated for a computer are replaced by an empty string,
+	// otherwise the server would drop the whole computer from the results
+	items := []string{
+		"id of it as string",
+		`(name of it | "")`,
+		`(operating system of it | "")`,
+		`(last report time of it as string | "")`,
+		`(cpu of it | "")`,
+		"(" + ComputerIPAddressRelevance + ` | "")`,
+	}
+	for _, name := range propertyNames {
+		items = append(items, fmt.Sprintf(`(concatenation "%%0a" of values of results (bes property %s, it) | "")`, RelevanceString(name)))
 	}
+	relevance := fmt.Sprintf("(%s) of bes computers whose (%s)", strings.Join(items, ", "), condition)
 
-	// Convert XML computers to Computer models
-	computers := make([]model.Computer, 0, len(result.Computers))
-	for _, computerXML := range result.Computers {
-		computer, err := computerXML.ToComputer()
+	var convertErr error
+	err := cs.client.Query.RunFunc(ctx, relevance, func(result model.QueryResult) bool {
+		computer, err := cs.computerFromResult(result, len(items), propertyNames)
 		if err != nil {
-			return nil, fmt.Errorf("failed to convert computer XML to model: %w", err)
+			convertErr = err
+			return false
 		}
-		computers = append(computers, *computer)
+		return fn(*computer)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch computers: %w", err)
 	}
+	return convertErr
+}
 
-	return computers, nil
+// computerFromResult converts a result of the ListWhereFunc query, holding the given number of items
+// ending with the values of the properties, to a Computer model
+func (cs *ComputerService) computerFromResult(result model.QueryResult, items int, propertyNames []string) (*model.Computer, error) {
+	if len(result.Values) != items {
+		return nil, fmt.Errorf("failed to fetch computers: unexpected query result with %d values", len(result.Values))
+	}
+
+	id, err := strconv.Atoi(result.Values[0].Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch computers: invalid computer id %q", result.Values[0].Value)
+	}
+
+	computerXML := model.ComputerListXML{
+		Resource:       cs.client.BaseURL + ":" + strconv.Itoa(cs.client.PortNumber) + "/api/computer/" + strconv.Itoa(id),
+		ID:             id,
+		Name:           result.Values[1].Value,
+		OS:             result.Values[2].Value,
+		LastReportTime: result.Values[3].Value,
+		CPU:            result.Values[4].Value,
+		IPAddress:      result.Values[5].Value,
+	}
+	for i, name := range propertyNames {
+		value := result.Values[items-len(propertyNames)+i].Value
+		if value == "" {
+			continue
+		}
+		for _, v := range strings.Split(value, "\n") {
+			computerXML.Properties = append(computerXML.Properties, model.Property{Name: name, Value: v})
+		}
+	}
+
+	computer, err := computerXML.ToComputer()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert computer XML to model: %w", err)
+	}
+	return computer, nil
+}
+
+// IsListableProperty reports whether the values of a retrieved property can be requested when listing computers.
+// Names containing characters used by the syntax of the fields parameter cannot.
+func IsListableProperty(name string) bool {
+	return name != "" && !strings.ContainsAny(name, ",&<>")
 }
 
 // Get retrieves a single computer by ID.
@@ -78,8 +198,9 @@ func (cs *ComputerService) Get(ctx context.Context, id int) (*model.Computer, er
 	endpoint := "/api/computer/" + fmt.Sprintf("%d", id) + "?fields" // Get all properties
 
 	// Perform the request with retry logic and limiter tag
-	resp, err := cs.client.executeWithRetryDefaultWithLimiter(func() (*resty.Response, error) {
+	resp, err := cs.client.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
 		return cs.client.Resty.R().
+			SetContext(ctx).
 			SetHeader("Accept", "application/xml").
 			Get(cs.client.BaseURL + ":" + strconv.Itoa(cs.client.PortNumber) + endpoint)
 	}, "bigfix_computer_get")
@@ -111,7 +232,7 @@ func (cs *ComputerService) Get(ctx context.Context, id int) (*model.Computer, er
 	// Ensure the ID is set from the URL parameter
 	computer.ID = id
 
-	plugin.Logger(ctx).Debug("API response computer:", computer)
+	cs.client.logger.Debug("API response", "computer", computer)
 
 	return computer, nil
 }
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// DefaultPort is the default port of the BigFix server REST API
+const DefaultPort = 52311
+
+// Environment variables read by ConfigFromEnv
+const (
+	EnvServerName = "BIGFIX_SERVER_NAME"
+	EnvPort       = "BIGFIX_PORT"
+	EnvUserName   = "BIGFIX_USER_NAME"
+	EnvPassword   = "BIGFIX_PASSWORD"
+)
+
+// Config holds the settings needed to connect to a BigFix server
+type Config struct {
+	ServerName string
+	Port       int
+	UserName   string
+	Password   string
+}
+
+// ConfigFromEnv returns the connection settings defined by the BIGFIX_SERVER_NAME, BIGFIX_PORT,
+// BIGFIX_USER_NAME and BIGFIX_PASSWORD environment variables. Port defaults to DefaultPort.
+func ConfigFromEnv() (Config, error) {
+	return Config{}.WithEnvDefaults()
+}
+
+// WithEnvDefaults returns the config with its missing settings taken from the BIGFIX_SERVER_NAME, BIGFIX_PORT,
+// BIGFIX_USER_NAME and BIGFIX_PASSWORD environment variables. BIGFIX_PORT is only read when the config has
+// no port, which then defaults to DefaultPort.
+func (c Config) WithEnvDefaults() (Config, error) {
+	if c.ServerName == "" {
+		c.ServerName = os.Getenv(EnvServerName)
+	}
+	if c.UserName == "" {
+		c.UserName = os.Getenv(EnvUserName)
+	}
+	if c.Password == "" {
+		c.Password = os.Getenv(EnvPassword)
+	}
+
+	if c.Port == 0 {
+		c.Port = DefaultPort
+		if port := os.Getenv(EnvPort); port != "" {
+			p, err := strconv.Atoi(port)
+			if err != nil {
+				return Config{}, fmt.Errorf("invalid %s: %s is not a port number", EnvPort, port)
+			}
+			c.Port = p
+		}
+	}
+
+	return c, nil
+}
+
+// Validate returns an error if a required setting is missing
+func (c Config) Validate() error {
+	switch {
+	case c.ServerName == "":
+		return fmt.Errorf("server name is required")
+	case c.UserName == "":
+		return fmt.Errorf("user name is required")
+	case c.Password == "":
+		return fmt.Errorf("password is required")
+	case c.Port <= 0 || c.Port > 65535:
+		return fmt.Errorf("invalid port: %d", c.Port)
+	}
+	return nil
+}
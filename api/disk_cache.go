@@ -0,0 +1,295 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"resty.dev/v3"
+)
+
+// diskCacheExt is the extension of the files holding cached documents
+const diskCacheExt = ".xml"
+
+// diskCacheTmpPrefix is the prefix of the temporary files documents are written to before being stored
+const diskCacheTmpPrefix = "tmp-"
+
+// diskCacheTmpMaxAge is the age after which a temporary file is considered left over by a crashed writer
+const diskCacheTmpMaxAge = time.Hour
+
+// DiskCache stores detail documents of the BigFix API on disk, keyed by a key identifying the resource and
+// by LastModified. As the LastModified attribute returned in list responses changes with every modification
+// of a fixlet, task, analysis or action, a cached document is always up to date for the version it was stored for.
+// The least recently used documents are evicted when the cache grows over its maximum size.
+// It is safe for concurrent use, and several processes can share the same directory.
+type DiskCache struct {
+	dir     string
+	maxSize int64
+
+	mu   sync.Mutex
+	size int64
+}
+
+// NewDiskCache creates a DiskCache storing up to maxSize bytes in dir, which is created if needed.
+// A maxSize of zero or less means no limit.
+func NewDiskCache(dir string, maxSize int64) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	dc := &DiskCache{
+		dir:     dir,
+		maxSize: maxSize,
+	}
+
+	dc.sweepTmp()
+
+	entries, err := dc.entries()
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		dc.size += entry.size
+	}
+
+	return dc, nil
+}
+
+// Dir returns the directory of the cache
+func (dc *DiskCache) Dir() string {
+	return dc.dir
+}
+
+// Get returns the document stored for the key and LastModified, if any
+func (dc *DiskCache) Get(key, lastModified string) ([]byte, bool) {
+	path := dc.path(key, lastModified)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	// Mark the document as recently used for eviction
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+
+	return data, true
+}
+
+// Put stores the document of the key and LastModified, evicting the least recently used
+// documents if the cache grows over its maximum size
+func (dc *DiskCache) Put(key, lastModified string, data []byte) error {
+	path := dc.path(key, lastModified)
+
+	// Write to a temporary file first so that readers never see a partial document
+	tmp, err := os.CreateTemp(dc.dir, diskCacheTmpPrefix+"*")
+	if err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	// A document replaced by the same key and LastModified, e.g. stored by another query in the meantime,
+	// no longer counts in the size
+	var replaced int64
+	if info, err := os.Stat(path); err == nil {
+		replaced = info.Size()
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	dc.size += int64(len(data)) - replaced
+	if dc.maxSize > 0 && dc.size > dc.maxSize {
+		return dc.evict()
+	}
+	return nil
+}
+
+// Purge removes all the documents from the cache
+func (dc *DiskCache) Purge() error {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+
+	entries, err := dc.entries()
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := os.Remove(entry.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to purge cache: %w", err)
+		}
+	}
+	dc.size = 0
+
+	return nil
+}
+
+// evict removes the least recently used documents until the cache fits in its maximum size.
+// The size is recomputed from the directory, as other processes may share it.
+func (dc *DiskCache) evict() error {
+	dc.sweepTmp()
+
+	entries, err := dc.entries()
+	if err != nil {
+		return err
+	}
+
+	dc.size = 0
+	for _, entry := range entries {
+		dc.size += entry.size
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].modTime.Before(entries[j].modTime)
+	})
+	for _, entry := range entries {
+		if dc.size <= dc.maxSize {
+			break
+		}
+		if err := os.Remove(entry.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to evict cache entry: %w", err)
+		}
+		dc.size -= entry.size
+	}
+
+	return nil
+}
+
+// sweepTmp removes the temporary files left over by writers which crashed before storing their document.
+// Recent ones are kept, as they may still be written by another process sharing the directory.
+func (dc *DiskCache) sweepTmp() {
+	dirEntries, err := os.ReadDir(dc.dir)
+	if err != nil {
+		return
+	}
+
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() || !strings.HasPrefix(dirEntry.Name(), diskCacheTmpPrefix) {
+			continue
+		}
+		info, err := dirEntry.Info()
+		if err != nil || time.Since(info.ModTime()) < diskCacheTmpMaxAge {
+			continue
+		}
+		_ = os.Remove(filepath.Join(dc.dir, dirEntry.Name()))
+	}
+}
+
+// diskCacheEntry describes a document stored in the cache
+type diskCacheEntry struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// entries lists the documents stored in the cache
+func (dc *DiskCache) entries() ([]diskCacheEntry, error) {
+	dirEntries, err := os.ReadDir(dc.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	var entries []diskCacheEntry
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() || !strings.HasSuffix(dirEntry.Name(), diskCacheExt) {
+			continue
+		}
+		info, err := dirEntry.Info()
+		if err != nil {
+			// Removed by another process in the meantime
+			continue
+		}
+		entries = append(entries, diskCacheEntry{
+			path:    filepath.Join(dc.dir, dirEntry.Name()),
+			size:    info.Size(),
+			modTime: info.ModTime(),
+		})
+	}
+
+	return entries, nil
+}
+
+// path returns the path of the file holding the document of the key and LastModified
+func (dc *DiskCache) path(key, lastModified string) string {
+	sum := sha256.Sum256([]byte(key + "\x00" + lastModified))
+	return filepath.Join(dc.dir, hex.EncodeToString(sum[:])+diskCacheExt)
+}
+
+// WithDiskCache sets the cache used to serve detail documents which did not change since they were last fetched
+func (c *Client) WithDiskCache(cache *DiskCache) *Client {
+	c.cache = cache
+	return c
+}
+
+// getDetail returns the body of the detail document at endpoint. When lastModified is set, as returned
+// for the resource in list responses, the document is served from the disk cache of the client if it was
+// stored for the same lastModified, and stored in the cache after being fetched otherwise.
+// Documents are cached per user, as the content visible to an operator depends on their permissions.
+func (c *Client) getDetail(ctx context.Context, endpoint, lastModified, limiterTag string) ([]byte, error) {
+	resourceURL := c.BaseURL + ":" + strconv.Itoa(c.PortNumber) + endpoint
+	cacheKey := c.userName + "@" + resourceURL
+
+	cache := c.cache
+	if lastModified == "" {
+		cache = nil
+	}
+
+	if cache != nil {
+		if body, ok := cache.Get(cacheKey, lastModified); ok {
+			c.logger.Debug("Served from the disk cache", "resource", resourceURL, "last_modified", lastModified)
+			return body, nil
+		}
+	}
+
+	// Perform the request with retry logic and limiter tag
+	resp, err := c.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
+		return c.Resty.R().
+			SetContext(ctx).
+			SetHeader("Accept", "application/xml").
+			Get(resourceURL)
+	}, limiterTag)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Body != nil {
+		defer resp.Body.Close()
+	}
+
+	// Read the response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if cache != nil {
+		if err := cache.Put(cacheKey, lastModified, body); err != nil {
+			// The document was fetched, a cache failure only costs a request next time
+			c.logger.Warn("Failed to store document in the disk cache", "resource", resourceURL, "error", err)
+		}
+	}
+
+	return body, nil
+}
@@ -0,0 +1,207 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDiskCacheGetPut(t *testing.T) {
+	cache, err := NewDiskCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := cache.Get("key", "v1"); ok {
+		t.Fatal("got a document from an empty cache")
+	}
+
+	if err := cache.Put("key", "v1", []byte("one")); err != nil {
+		t.Fatal(err)
+	}
+	if data, ok := cache.Get("key", "v1"); !ok || string(data) != "one" {
+		t.Errorf("got %q, %v for the stored version", data, ok)
+	}
+
+	// A new LastModified misses the document stored for the previous one
+	if _, ok := cache.Get("key", "v2"); ok {
+		t.Error("got a document stored for another LastModified")
+	}
+	if _, ok := cache.Get("other", "v1"); ok {
+		t.Error("got a document stored for another key")
+	}
+}
+
+func TestDiskCachePutOverwriteSize(t *testing.T) {
+	cache, err := NewDiskCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := cache.Put("key", "v1", []byte("0123456789")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if cache.size != 10 {
+		t.Errorf("got size %d after overwriting the same document, want 10", cache.size)
+	}
+}
+
+func TestDiskCacheEvict(t *testing.T) {
+	cache, err := NewDiskCache(t.TempDir(), 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("0123456789")
+	for _, key := range []string{"a", "b"} {
+		if err := cache.Put(key, "v1", data); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Make a the most recently used document, leaving b to be evicted first
+	past := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(cache.path("b", "v1"), past, past); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(cache.path("a", "v1"), past, past); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.Get("a", "v1"); !ok {
+		t.Fatal("missing document a")
+	}
+
+	if err := cache.Put("c", "v1", data); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := cache.Get("b", "v1"); ok {
+		t.Error("the least recently used document was not evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := cache.Get(key, "v1"); !ok {
+			t.Errorf("document %s was evicted", key)
+		}
+	}
+	if cache.size != 20 {
+		t.Errorf("got size %d, want 20", cache.size)
+	}
+}
+
+func TestDiskCacheSweepTmp(t *testing.T) {
+	dir := t.TempDir()
+
+	stale := filepath.Join(dir, diskCacheTmpPrefix+"stale")
+	recent := filepath.Join(dir, diskCacheTmpPrefix+"recent")
+	for _, path := range []string{stale, recent} {
+		if err := os.WriteFile(path, []byte("partial"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	past := time.Now().Add(-2 * diskCacheTmpMaxAge)
+	if err := os.Chtimes(stale, past, past); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewDiskCache(dir, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Error("the stale temporary file was not removed")
+	}
+	if _, err := os.Stat(recent); err != nil {
+		t.Error("a temporary file possibly being written was removed")
+	}
+}
+
+func TestDiskCachePurge(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewDiskCache(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.Put("key", "v1", []byte("one")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cache.Purge(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.Get("key", "v1"); ok {
+		t.Error("got a purged document")
+	}
+	if cache.size != 0 {
+		t.Errorf("got size %d after purge", cache.size)
+	}
+
+	// The size of documents already stored is counted when the cache is opened again
+	if err := cache.Put("key", "v1", []byte("one")); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := NewDiskCache(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reopened.size != 3 {
+		t.Errorf("got size %d for the reopened cache, want 3", reopened.size)
+	}
+}
+
+func TestGetDetailDiskCache(t *testing.T) {
+	var requests atomic.Int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write([]byte("<BES>" + r.URL.Path + "</BES>"))
+	})
+
+	cache, err := NewDiskCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, _ := newTestClient(t, handler)
+	client = client.WithDiskCache(cache)
+
+	// Another user of the same server does not share the cached documents
+	other, _ := newTestClient(t, handler, WithBasicAuth("other", "password"))
+	other.BaseURL = client.BaseURL
+	other.PortNumber = client.PortNumber
+	other = other.WithDiskCache(cache)
+
+	tests := []struct {
+		name         string
+		client       *Client
+		endpoint     string
+		lastModified string
+		wantRequests int32
+	}{
+		{name: "first fetch", client: client, endpoint: "/api/fixlet/a/1", lastModified: "v1", wantRequests: 1},
+		{name: "unchanged", client: client, endpoint: "/api/fixlet/a/1", lastModified: "v1", wantRequests: 1},
+		{name: "modified", client: client, endpoint: "/api/fixlet/a/1", lastModified: "v2", wantRequests: 2},
+		{name: "other resource", client: client, endpoint: "/api/fixlet/a/2", lastModified: "v2", wantRequests: 3},
+		{name: "no LastModified", client: client, endpoint: "/api/fixlet/a/1", lastModified: "", wantRequests: 4},
+		{name: "other user", client: other, endpoint: "/api/fixlet/a/1", lastModified: "v2", wantRequests: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := tt.client.getDetail(context.Background(), tt.endpoint, tt.lastModified, "test")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(body) != "<BES>"+tt.endpoint+"</BES>" {
+				t.Errorf("got body %q", body)
+			}
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("got %d requests, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
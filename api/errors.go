@@ -0,0 +1,151 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"resty.dev/v3"
+)
+
+// RetryError is returned when a request did not succeed within the allowed attempts,
+// or was abandoned because its context was cancelled
+type RetryError struct {
+	// Attempts is the number of attempts made before giving up
+	Attempts int
+	// StatusCode is the HTTP status code of the last response, 0 if no response was received
+	StatusCode int
+	// Err is the error of the last attempt
+	Err error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("request failed after %d attempts: %v", e.Attempts, e.Err)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorClass classifies an Error by the kind of failure reported by the BigFix server
+type ErrorClass string
+
+const (
+	ErrorClassNotFound     ErrorClass = "NotFound"
+	ErrorClassUnauthorized ErrorClass = "Unauthorized"
+	ErrorClassForbidden    ErrorClass = "Forbidden"
+	ErrorClassRateLimited  ErrorClass = "RateLimited"
+	ErrorClassServerError  ErrorClass = "ServerError"
+	ErrorClassClientError  ErrorClass = "ClientError"
+	ErrorClassUnknown      ErrorClass = "Unknown"
+)
+
+// maxErrorMessageLength caps the length of the response body kept in an Error
+const maxErrorMessageLength = 512
+
+// Error is returned when the BigFix server answers a request with an unsuccessful status code
+type Error struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+	// Endpoint is the path and query of the request
+	Endpoint string
+	// Message is the error text returned by the BigFix server in the response body
+	Message string
+	// Class classifies the error by the kind of failure
+	Class ErrorClass
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("%s: %d %s", e.Endpoint, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// newError creates an Error from an unsuccessful response
+func newError(resp *resty.Response) *Error {
+	e := &Error{
+		StatusCode: resp.StatusCode(),
+		Class:      classifyStatusCode(resp.StatusCode()),
+		Message:    resp.String(),
+	}
+
+	if resp.RawResponse != nil && resp.RawResponse.Request != nil {
+		e.Endpoint = resp.RawResponse.Request.URL.RequestURI()
+	} else if resp.Request != nil {
+		e.Endpoint = resp.Request.URL
+	}
+
+	if len(e.Message) > maxErrorMessageLength {
+		e.Message = e.Message[:maxErrorMessageLength] + "..."
+	}
+
+	return e
+}
+
+// classifyStatusCode returns the ErrorClass of an HTTP status code
+func classifyStatusCode(statusCode int) ErrorClass {
+	switch {
+	case statusCode == http.StatusNotFound:
+		return ErrorClassNotFound
+	case statusCode == http.StatusUnauthorized:
+		return ErrorClassUnauthorized
+	case statusCode == http.StatusForbidden:
+		return ErrorClassForbidden
+	case statusCode == http.StatusTooManyRequests:
+		return ErrorClassRateLimited
+	case statusCode >= 500:
+		return ErrorClassServerError
+	case statusCode >= 400:
+		return ErrorClassClientError
+	}
+	return ErrorClassUnknown
+}
+
+// AsError returns the Error in the chain of err, if any
+func AsError(err error) (*Error, bool) {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// StatusCode returns the HTTP status code of the Error in the chain of err, or 0 if there is none
+func StatusCode(err error) int {
+	if apiErr, ok := AsError(err); ok {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound reports whether err is an Error for a resource that does not exist
+func IsNotFound(err error) bool {
+	return hasErrorClass(err, ErrorClassNotFound)
+}
+
+// IsUnauthorized reports whether err is an Error for a request with missing or invalid credentials
+func IsUnauthorized(err error) bool {
+	return hasErrorClass(err, ErrorClassUnauthorized)
+}
+
+// IsForbidden reports whether err is an Error for a request the operator is not allowed to make
+func IsForbidden(err error) bool {
+	return hasErrorClass(err, ErrorClassForbidden)
+}
+
+// IsRateLimited reports whether err is an Error for a request rejected by server side rate limiting
+func IsRateLimited(err error) bool {
+	return hasErrorClass(err, ErrorClassRateLimited)
+}
+
+// IsServerError reports whether err is an Error for a request the server failed to process
+func IsServerError(err error) bool {
+	return hasErrorClass(err, ErrorClassServerError)
+}
+
+func hasErrorClass(err error, class ErrorClass) bool {
+	apiErr, ok := AsError(err)
+	return ok && apiErr.Class == class
+}
@@ -4,12 +4,10 @@ import (
 	"context"
 	"encoding/xml"
 	"fmt"
-	"io"
 	"net/url"
 	"strconv"
 
 	"github.com/turbot/steampipe-plugin-bigfix/api/model"
-	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"resty.dev/v3"
 )
 
@@ -27,6 +25,22 @@ func NewFixletService(client *Client) *FixletService {
 
 // List retrieves all fixlets for a specific site
 func (fs *FixletService) List(ctx context.Context, siteName string, siteType string) ([]model.Fixlet, error) {
+	var fixlets []model.Fixlet
+	err := fs.ListFunc(ctx, siteName, siteType, func(fixlet model.Fixlet) bool {
+		fixlets = append(fixlets, fixlet)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fs.client.logger.Debug("API response", "fixlets", fixlets)
+
+	return fixlets, nil
+}
+
+// ListFunc streams the fixlets of the site to fn as they are decoded from the response, stopping as soon as fn returns false
+func (fs *FixletService) ListFunc(ctx context.Context, siteName string, siteType string, fn func(model.Fixlet) bool) error {
 	var endpoint string
 
 	switch siteType {
@@ -38,50 +52,81 @@ func (fs *FixletService) List(ctx context.Context, siteName string, siteType str
 		endpoint = "/api/fixlets/master"
 	case "action":
 		endpoint = "/api/fixlets/action/" + url.PathEscape(siteName)
+	case "custom":
+		endpoint = "/api/fixlets/custom/" + url.PathEscape(siteName)
 	default:
-		return nil, fmt.Errorf("invalid site type: %s. Must be one of: external, operator, master, action", siteType)
+		return fmt.Errorf("invalid site type: %s. Must be one of: external, operator, master, action, custom", siteType)
 	}
 
 	// Perform the request with retry logic and limiter tag
-	resp, err := fs.client.executeWithRetryDefaultWithLimiter(func() (*resty.Response, error) {
+	resp, err := fs.client.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
 		return fs.client.Resty.R().
+			SetContext(ctx).
 			SetHeader("Accept", "application/xml").
 			Get(fs.client.BaseURL + ":" + strconv.Itoa(fs.client.PortNumber) + endpoint)
 	}, "bigfix_fixlet_list")
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch fixlets for site %s (%s): %w", siteName, siteType, err)
+		return fmt.Errorf("failed to fetch fixlets for site %s (%s): %w", siteName, siteType, err)
 	}
 
 	if resp.Body != nil {
 		defer resp.Body.Close()
 	}
 
-	// Read the response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
+	// Decode the fixlets one at a time, setting the site information on each
+	return decodeXMLList(resp.Body, "Fixlet", func(fixlet model.Fixlet) bool {
+		fixlet.SiteName = siteName
+		fixlet.SiteType = siteType
+		return fn(fixlet)
+	})
+}
 
-	// Parse XML for fixlets response
-	var result model.FixletListResponse
-	if err := xml.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse XML response: %w", err)
+// ListDetails retrieves the title, category, source severity, CVE names and source release date of all
+// the fixlets of a site with a single session relevance query, keyed by fixlet ID. This is much faster
+// than calling Get for each fixlet of large sites. The relevance is left out, as session relevance only
+// returns the clauses of a fixlet combined into a single expression.
+func (fs *FixletService) ListDetails(ctx context.Context, siteName string, siteType string) (map[int]model.Fixlet, error) {
+	rows, err := fs.client.querySiteContent(ctx, siteName, siteType, fixletFlag, []string{
+		"name of it",
+		"category of it",
+		"source severity of it",
+		"cve id list of it",
+		"source release date of it as string",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch fixlet details for site %s (%s): %w", siteName, siteType, err)
 	}
 
-	// Set site information for each fixlet
-	for i := range result.Fixlets {
-		result.Fixlets[i].SiteName = siteName
-		result.Fixlets[i].SiteType = siteType
+	fixlets := make(map[int]model.Fixlet, len(rows))
+	for id, row := range rows {
+		fixlets[id] = model.Fixlet{
+			ID:                id,
+			Name:              row[0],
+			SiteName:          siteName,
+			SiteType:          siteType,
+			Title:             row[0],
+			Category:          row[1],
+			SourceSeverity:    row[2],
+			CVENames:          row[3],
+			SourceReleaseDate: relevanceDate(row[4]),
+			DetailsFetched:    true,
+		}
 	}
 
-	plugin.Logger(ctx).Debug("API response fixlets:", result.Fixlets)
+	fs.client.logger.Debug("API response", "fixlet_details", len(fixlets))
 
-	return result.Fixlets, nil
+	return fixlets, nil
 }
 
 // Get retrieves a specific fixlet detail
 func (fs *FixletService) Get(ctx context.Context, siteName string, siteType string, fixletID int) (*model.Fixlet, error) {
+	return fs.GetCached(ctx, siteName, siteType, fixletID, "")
+}
+
+// GetCached retrieves a specific fixlet detail like Get. When lastModified is set, as returned for the fixlet
+// in list responses, the detail is served from the disk cache of the client if it did not change.
+func (fs *FixletService) GetCached(ctx context.Context, siteName string, siteType string, fixletID int, lastModified string) (*model.Fixlet, error) {
 	var endpoint string
 
 	switch siteType {
@@ -93,31 +138,18 @@ func (fs *FixletService) Get(ctx context.Context, siteName string, siteType stri
 		endpoint = "/api/fixlet/master/" + strconv.Itoa(fixletID)
 	case "action":
 		endpoint = "/api/fixlet/action/" + url.PathEscape(siteName) + "/" + strconv.Itoa(fixletID)
+	case "custom":
+		endpoint = "/api/fixlet/custom/" + url.PathEscape(siteName) + "/" + strconv.Itoa(fixletID)
 	default:
-		return nil, fmt.Errorf("invalid site type: %s. Must be one of: external, operator, master, action", siteType)
+		return nil, fmt.Errorf("invalid site type: %s. Must be one of: external, operator, master, action, custom", siteType)
 	}
 
-	// Perform the request with retry logic and limiter tag
-	resp, err := fs.client.executeWithRetryDefaultWithLimiter(func() (*resty.Response, error) {
-		return fs.client.Resty.R().
-			SetHeader("Accept", "application/xml").
-			Get(fs.client.BaseURL + ":" + strconv.Itoa(fs.client.PortNumber) + endpoint)
-	}, "bigfix_fixlet_get")
-
+	// Fetch the detail, from the disk cache when unchanged
+	body, err := fs.client.getDetail(ctx, endpoint, lastModified, "bigfix_fixlet_get")
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch fixlet %d for site %s (%s): %w", fixletID, siteName, siteType, err)
 	}
 
-	if resp.Body != nil {
-		defer resp.Body.Close()
-	}
-
-	// Read the response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
 	// Parse XML for fixlet detail response
 	var result model.FixletDetailResponse
 	if err := xml.Unmarshal(body, &result); err != nil {
@@ -128,7 +160,7 @@ func (fs *FixletService) Get(ctx context.Context, siteName string, siteType stri
 	resourceURL := fs.client.BaseURL + ":" + strconv.Itoa(fs.client.PortNumber) + endpoint
 	fixlet := result.Fixlet.ToFixlet(fixletID, resourceURL, siteName, siteType)
 
-	plugin.Logger(ctx).Debug("API response fixlet:", fixlet)
+	fs.client.logger.Debug("API response", "fixlet", fixlet)
 
 	return fixlet, nil
 }
@@ -1,6 +1,11 @@
 package model
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // ActionListResponse represents the XML response for action list
 type ActionListResponse struct {
@@ -22,12 +27,16 @@ type Action struct {
 	SettingsLocks   *ActionSettingsLocks `json:"settings_locks,omitempty"`
 	Target          *ActionTarget        `json:"target,omitempty"`
 	IsUrgent        bool                 `json:"is_urgent,omitempty"`
+	ActionType      string               `json:"action_type,omitempty"` // "single", "multiple", "baseline"
+	MemberActions   []ActionMember       `json:"member_actions,omitempty"`
 }
 
 // ActionDetailResponse represents the XML response for action detail
 type ActionDetailResponse struct {
-	XMLName xml.Name     `xml:"BES"`
-	Action  ActionDetail `xml:"SingleAction"`
+	XMLName             xml.Name                   `xml:"BES"`
+	Action              *ActionDetail              `xml:"SingleAction,omitempty"`
+	MultipleActionGroup *MultipleActionGroupDetail `xml:"MultipleActionGroup,omitempty"`
+	BaselineAction      *BaselineActionDetail      `xml:"BaselineAction,omitempty"`
 }
 
 // ActionDetail represents detailed action information
@@ -42,6 +51,62 @@ type ActionDetail struct {
 	IsUrgent        bool                 `xml:"IsUrgent" json:"is_urgent,omitempty"`
 }
 
+// MultipleActionGroupDetail represents detailed multiple action group information
+type MultipleActionGroupDetail struct {
+	Title                 string               `xml:"Title"`
+	Relevance             string               `xml:"Relevance"`
+	PreGroupActionScript  ActionScript         `xml:"PreGroupActionScript"`
+	MemberActions         []MemberActionDetail `xml:"MemberAction"`
+	PostGroupActionScript ActionScript         `xml:"PostGroupActionScript"`
+	SuccessCriteria       string               `xml:"SuccessCriteria"`
+	Settings              *ActionSettings      `xml:"Settings"`
+	SettingsLocks         *ActionSettingsLocks `xml:"SettingsLocks"`
+	Target                *ActionTarget        `xml:"Target"`
+	IsUrgent              bool                 `xml:"IsUrgent"`
+}
+
+// MemberActionDetail represents a member action of a multiple action group
+type MemberActionDetail struct {
+	Title                   string              `xml:"Title"`
+	Relevance               string              `xml:"Relevance"`
+	ActionScript            ActionScript        `xml:"ActionScript"`
+	SuccessCriteria         string              `xml:"SuccessCriteria"`
+	IncludeInGroupRelevance bool                `xml:"IncludeInGroupRelevance"`
+	SourceFixlet            *ActionSourceFixlet `xml:"SourceFixlet"`
+}
+
+// ActionSourceFixlet represents the fixlet an action was taken from
+type ActionSourceFixlet struct {
+	SiteName string `xml:"Sitename" json:"site_name,omitempty"`
+	FixletID int    `xml:"FixletID" json:"fixlet_id,omitempty"`
+	Action   string `xml:"Action" json:"action,omitempty"`
+}
+
+// BaselineActionDetail represents detailed baseline action information
+type BaselineActionDetail struct {
+	Title                       string                      `xml:"Title"`
+	Relevance                   string                      `xml:"Relevance"`
+	SuccessCriteria             string                      `xml:"SuccessCriteria"`
+	BaselineComponentCollection BaselineComponentCollection `xml:"BaselineComponentCollection"`
+	Settings                    *ActionSettings             `xml:"Settings"`
+	SettingsLocks               *ActionSettingsLocks        `xml:"SettingsLocks"`
+	Target                      *ActionTarget               `xml:"Target"`
+	IsUrgent                    bool                        `xml:"IsUrgent"`
+}
+
+// ActionMember represents a member action of a multiple action group or baseline action for API return
+type ActionMember struct {
+	Index                   int                 `json:"index"`
+	GroupName               string              `json:"group_name,omitempty"`
+	Title                   string              `json:"title"`
+	Relevance               string              `json:"relevance,omitempty"`
+	ActionScript            string              `json:"action_script,omitempty"`
+	SuccessCriteria         string              `json:"success_criteria,omitempty"`
+	IncludeInGroupRelevance bool                `json:"include_in_group_relevance"`
+	SourceFixlet            *ActionSourceFixlet `json:"source_fixlet,omitempty"`
+	SourceSiteURL           string              `json:"source_site_url,omitempty"`
+}
+
 // ActionScript represents the action script with MIME type
 type ActionScript struct {
 	MIMEType string `xml:"MIMEType,attr" json:"mime_type,omitempty"`
@@ -50,32 +115,85 @@ type ActionScript struct {
 
 // ActionSettings represents action settings
 type ActionSettings struct {
-	PreActionShowUI         bool               `xml:"PreActionShowUI" json:"pre_action_show_ui,omitempty"`
-	HasRunningMessage       bool               `xml:"HasRunningMessage" json:"has_running_message,omitempty"`
-	HasTimeRange            bool               `xml:"HasTimeRange" json:"has_time_range,omitempty"`
-	HasStartTime            bool               `xml:"HasStartTime" json:"has_start_time,omitempty"`
-	HasEndTime              bool               `xml:"HasEndTime" json:"has_end_time,omitempty"`
-	EndDateTimeLocalOffset  string             `xml:"EndDateTimeLocalOffset" json:"end_date_time_local_offset,omitempty"`
-	HasDayOfWeekConstraint  bool               `xml:"HasDayOfWeekConstraint" json:"has_day_of_week_constraint,omitempty"`
-	UseUTCTime              bool               `xml:"UseUTCTime" json:"use_utc_time,omitempty"`
-	ActiveUserRequirement   string             `xml:"ActiveUserRequirement" json:"active_user_requirement,omitempty"`
-	ActiveUserType          string             `xml:"ActiveUserType" json:"active_user_type,omitempty"`
-	HasWhose                bool               `xml:"HasWhose" json:"has_whose,omitempty"`
-	PreActionCacheDownload  bool               `xml:"PreActionCacheDownload" json:"pre_action_cache_download,omitempty"`
-	Reapply                 bool               `xml:"Reapply" json:"reapply,omitempty"`
-	HasReapplyLimit         bool               `xml:"HasReapplyLimit" json:"has_reapply_limit,omitempty"`
-	ReapplyLimit            int                `xml:"ReapplyLimit" json:"reapply_limit,omitempty"`
-	HasReapplyInterval      bool               `xml:"HasReapplyInterval" json:"has_reapply_interval,omitempty"`
-	HasRetry                bool               `xml:"HasRetry" json:"has_retry,omitempty"`
-	HasTemporalDistribution bool               `xml:"HasTemporalDistribution" json:"has_temporal_distribution,omitempty"`
-	ContinueOnErrors        bool               `xml:"ContinueOnErrors" json:"continue_on_errors,omitempty"`
-	PostActionBehavior      PostActionBehavior `xml:"PostActionBehavior" json:"post_action_behavior,omitempty"`
-	IsOffer                 bool               `xml:"IsOffer" json:"is_offer,omitempty"`
+	PreActionShowUI          bool                 `xml:"PreActionShowUI" json:"pre_action_show_ui,omitempty"`
+	HasRunningMessage        bool                 `xml:"HasRunningMessage" json:"has_running_message,omitempty"`
+	RunningMessage           *RunningMessage      `xml:"RunningMessage" json:"running_message,omitempty"`
+	HasTimeRange             bool                 `xml:"HasTimeRange" json:"has_time_range,omitempty"`
+	TimeRange                *ActionTimeRange     `xml:"TimeRange" json:"time_range,omitempty"`
+	HasStartTime             bool                 `xml:"HasStartTime" json:"has_start_time,omitempty"`
+	StartDateTimeOffset      *string              `xml:"StartDateTimeOffset" json:"start_date_time_offset,omitempty"`
+	StartDateTimeLocal       *string              `xml:"StartDateTimeLocal" json:"start_date_time_local,omitempty"`
+	StartDateTimeLocalOffset *string              `xml:"StartDateTimeLocalOffset" json:"start_date_time_local_offset,omitempty"`
+	HasEndTime               bool                 `xml:"HasEndTime" json:"has_end_time,omitempty"`
+	EndDateTimeOffset        *string              `xml:"EndDateTimeOffset" json:"end_date_time_offset,omitempty"`
+	EndDateTimeLocal         *string              `xml:"EndDateTimeLocal" json:"end_date_time_local,omitempty"`
+	EndDateTimeLocalOffset   *string              `xml:"EndDateTimeLocalOffset" json:"end_date_time_local_offset,omitempty"`
+	HasDayOfWeekConstraint   bool                 `xml:"HasDayOfWeekConstraint" json:"has_day_of_week_constraint,omitempty"`
+	DayOfWeekConstraint      *DayOfWeekConstraint `xml:"DayOfWeekConstraint" json:"day_of_week_constraint,omitempty"`
+	UseUTCTime               bool                 `xml:"UseUTCTime" json:"use_utc_time,omitempty"`
+	ActiveUserRequirement    string               `xml:"ActiveUserRequirement" json:"active_user_requirement,omitempty"`
+	ActiveUserType           string               `xml:"ActiveUserType" json:"active_user_type,omitempty"`
+	HasWhose                 bool                 `xml:"HasWhose" json:"has_whose,omitempty"`
+	Whose                    *ActionWhose         `xml:"Whose" json:"whose,omitempty"`
+	PreActionCacheDownload   bool                 `xml:"PreActionCacheDownload" json:"pre_action_cache_download,omitempty"`
+	Reapply                  bool                 `xml:"Reapply" json:"reapply,omitempty"`
+	HasReapplyLimit          bool                 `xml:"HasReapplyLimit" json:"has_reapply_limit,omitempty"`
+	ReapplyLimit             int                  `xml:"ReapplyLimit" json:"reapply_limit,omitempty"`
+	HasReapplyInterval       bool                 `xml:"HasReapplyInterval" json:"has_reapply_interval,omitempty"`
+	ReapplyInterval          *string              `xml:"ReapplyInterval" json:"reapply_interval,omitempty"`
+	HasRetry                 bool                 `xml:"HasRetry" json:"has_retry,omitempty"`
+	RetryCount               *int                 `xml:"RetryCount" json:"retry_count,omitempty"`
+	RetryWait                *ActionRetryWait     `xml:"RetryWait" json:"retry_wait,omitempty"`
+	HasTemporalDistribution  bool                 `xml:"HasTemporalDistribution" json:"has_temporal_distribution,omitempty"`
+	TemporalDistribution     *string              `xml:"TemporalDistribution" json:"temporal_distribution,omitempty"`
+	ContinueOnErrors         bool                 `xml:"ContinueOnErrors" json:"continue_on_errors,omitempty"`
+	PostActionBehavior       PostActionBehavior   `xml:"PostActionBehavior" json:"post_action_behavior,omitempty"`
+	IsOffer                  bool                 `xml:"IsOffer" json:"is_offer,omitempty"`
+}
+
+// RunningMessage represents the message shown to users while an action runs
+type RunningMessage struct {
+	Title string `xml:"Title" json:"title,omitempty"`
+	Text  string `xml:"Text" json:"text,omitempty"`
+}
+
+// ActionTimeRange represents the daily time window an action may run in
+type ActionTimeRange struct {
+	StartTime string `xml:"StartTime" json:"start_time,omitempty"`
+	EndTime   string `xml:"EndTime" json:"end_time,omitempty"`
+}
+
+// DayOfWeekConstraint represents the days of the week an action may run on
+type DayOfWeekConstraint struct {
+	Sun bool `xml:"Sun" json:"sun"`
+	Mon bool `xml:"Mon" json:"mon"`
+	Tue bool `xml:"Tue" json:"tue"`
+	Wed bool `xml:"Wed" json:"wed"`
+	Thu bool `xml:"Thu" json:"thu"`
+	Fri bool `xml:"Fri" json:"fri"`
+	Sat bool `xml:"Sat" json:"sat"`
+}
+
+// ActionWhose represents a retrieved property constraint on the computers that run an action
+type ActionWhose struct {
+	Property string `xml:"Property" json:"property,omitempty"`
+	Relation string `xml:"Relation" json:"relation,omitempty"`
+	Value    string `xml:"Value" json:"value,omitempty"`
+}
+
+// ActionRetryWait represents how long to wait between retries of a failed action
+type ActionRetryWait struct {
+	Behavior string `xml:"Behavior,attr" json:"behavior,omitempty"`
+	Value    string `xml:",chardata" json:"value,omitempty"`
 }
 
 // PostActionBehavior represents post action behavior settings
 type PostActionBehavior struct {
-	Behavior string `xml:"Behavior,attr" json:"behavior,omitempty"`
+	Behavior    string `xml:"Behavior,attr" json:"behavior,omitempty"`
+	AllowCancel bool   `xml:"AllowCancel" json:"allow_cancel,omitempty"`
+	Deadline    string `xml:"Deadline" json:"deadline,omitempty"`
+	Title       string `xml:"Title" json:"title,omitempty"`
+	Text        string `xml:"Text" json:"text,omitempty"`
 }
 
 // ActionSettingsLocks represents action settings locks
@@ -133,7 +251,32 @@ type PostActionBehaviorLocks struct {
 // ActionTarget represents action target
 type ActionTarget struct {
 	AllComputers bool `xml:"AllComputers" json:"all_computers,omitempty"`
-	ComputerID   int  `xml:"ComputerID" json:"computer_id,omitempty"`
+	// ComputerID is the first targeted computer, kept for compatibility with queries written before ComputerIDs
+	ComputerID      int                 `xml:"-" json:"computer_id,omitempty"`
+	ComputerIDs     []int               `xml:"ComputerID" json:"computer_ids,omitempty"`
+	ComputerNames   []string            `xml:"ComputerName" json:"computer_names,omitempty"`
+	CustomRelevance *string             `xml:"CustomRelevance" json:"custom_relevance,omitempty"`
+	ComputerGroups  []ActionTargetGroup `xml:"ComputerGroup" json:"computer_groups,omitempty"`
+}
+
+// UnmarshalXML decodes an action target, setting ComputerID from the targeted computers
+func (t *ActionTarget) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type actionTarget ActionTarget
+	if err := d.DecodeElement((*actionTarget)(t), &start); err != nil {
+		return err
+	}
+
+	if len(t.ComputerIDs) > 0 {
+		t.ComputerID = t.ComputerIDs[0]
+	}
+	return nil
+}
+
+// ActionTargetGroup represents a computer group targeted by an action
+type ActionTargetGroup struct {
+	Resource string `xml:"Resource,attr" json:"resource,omitempty"`
+	ID       int    `xml:"ID" json:"id,omitempty"`
+	Name     string `xml:"Name" json:"name,omitempty"`
 }
 
 // ToAction converts ActionDetail to Action model
@@ -150,6 +293,95 @@ func (ad *ActionDetail) ToAction(id int, resource string) *Action {
 		SettingsLocks:   ad.SettingsLocks,
 		Target:          ad.Target,
 		IsUrgent:        ad.IsUrgent,
+		ActionType:      "single",
+	}
+}
+
+// ToAction converts MultipleActionGroupDetail to Action model
+func (mag *MultipleActionGroupDetail) ToAction(id int, resource string) *Action {
+	action := &Action{
+		ID:              id,
+		Resource:        resource,
+		Name:            mag.Title,
+		Title:           mag.Title,
+		Relevance:       mag.Relevance,
+		SuccessCriteria: mag.SuccessCriteria,
+		Settings:        mag.Settings,
+		SettingsLocks:   mag.SettingsLocks,
+		Target:          mag.Target,
+		IsUrgent:        mag.IsUrgent,
+		ActionType:      "multiple",
+	}
+
+	for _, member := range mag.MemberActions {
+		action.MemberActions = append(action.MemberActions, ActionMember{
+			Index:                   len(action.MemberActions),
+			Title:                   member.Title,
+			Relevance:               member.Relevance,
+			ActionScript:            member.ActionScript.Content,
+			SuccessCriteria:         member.SuccessCriteria,
+			IncludeInGroupRelevance: member.IncludeInGroupRelevance,
+			SourceFixlet:            member.SourceFixlet,
+		})
+	}
+
+	return action
+}
+
+// ToAction converts BaselineActionDetail to Action model
+func (bad *BaselineActionDetail) ToAction(id int, resource string) *Action {
+	action := &Action{
+		ID:              id,
+		Resource:        resource,
+		Name:            bad.Title,
+		Title:           bad.Title,
+		Relevance:       bad.Relevance,
+		SuccessCriteria: bad.SuccessCriteria,
+		Settings:        bad.Settings,
+		SettingsLocks:   bad.SettingsLocks,
+		Target:          bad.Target,
+		IsUrgent:        bad.IsUrgent,
+		ActionType:      "baseline",
+	}
+
+	for _, group := range bad.BaselineComponentCollection.Groups {
+		for _, component := range group.Components {
+			member := ActionMember{
+				Index:                   len(action.MemberActions),
+				GroupName:               group.Name,
+				Title:                   component.Name,
+				Relevance:               component.Relevance,
+				ActionScript:            component.ActionScript,
+				SuccessCriteria:         component.SuccessCriteria,
+				IncludeInGroupRelevance: strings.EqualFold(component.IncludeInRelevance, "true"),
+				SourceSiteURL:           component.SourceSiteURL,
+			}
+			if fixletID, err := strconv.Atoi(component.SourceID); err == nil {
+				member.SourceFixlet = &ActionSourceFixlet{
+					FixletID: fixletID,
+					Action:   component.ActionName,
+				}
+			}
+			action.MemberActions = append(action.MemberActions, member)
+		}
+	}
+
+	return action
+}
+
+// ToAction converts whichever action kind the detail response carries to Action model
+func (adr *ActionDetailResponse) ToAction(id int, resource string) *Action {
+	switch {
+	case adr.Action != nil:
+		return adr.Action.ToAction(id, resource)
+	case adr.MultipleActionGroup != nil:
+		return adr.MultipleActionGroup.ToAction(id, resource)
+	case adr.BaselineAction != nil:
+		return adr.BaselineAction.ToAction(id, resource)
+	}
+	return &Action{
+		ID:       id,
+		Resource: resource,
 	}
 }
 
@@ -163,3 +395,100 @@ func (a *Action) ToActionFromList() *Action {
 		LastModified: a.LastModified,
 	}
 }
+
+// ActionStatusResponse represents the XML response for action status
+type ActionStatusResponse struct {
+	XMLName       xml.Name         `xml:"BESAPI"`
+	ActionResults ActionResultsXML `xml:"ActionResults"`
+}
+
+// ActionResultsXML represents the overall status of an action and its per-computer results
+type ActionResultsXML struct {
+	Resource   string                    `xml:"Resource,attr"`
+	ActionID   int                       `xml:"ActionID"`
+	Status     string                    `xml:"Status"`
+	DateIssued string                    `xml:"DateIssued"`
+	Computers  []ActionComputerResultXML `xml:"Computer"`
+}
+
+// ActionComputerResultXML represents the result of an action on a single computer
+type ActionComputerResultXML struct {
+	ID         int               `xml:"ID,attr"`
+	Name       string            `xml:"Name,attr"`
+	Status     string            `xml:"Status"`
+	State      ActionResultState `xml:"State"`
+	ApplyCount int               `xml:"ApplyCount"`
+	RetryCount int               `xml:"RetryCount"`
+	LineNumber int               `xml:"LineNumber"`
+	StartTime  string            `xml:"StartTime"`
+	EndTime    string            `xml:"EndTime"`
+	ExitCode   *int              `xml:"ExitCode"`
+}
+
+// ActionResultState represents the state of an action on a computer
+type ActionResultState struct {
+	IsError int    `xml:"IsError,attr"`
+	Value   string `xml:",chardata"`
+}
+
+// ActionComputerStatus represents the status of an action on a single computer for API return.
+// An action without any computer result is represented by a single status holding only the
+// action fields, with a zero ComputerID.
+type ActionComputerStatus struct {
+	Resource     string     `json:"resource,omitempty"`
+	ActionID     int        `json:"action_id"`
+	ActionStatus string     `json:"action_status,omitempty"`
+	DateIssued   *time.Time `json:"date_issued,omitempty"`
+	ComputerID   int        `json:"computer_id"`
+	ComputerName string     `json:"computer_name,omitempty"`
+	Status       string     `json:"status,omitempty"`
+	State        string     `json:"state,omitempty"`
+	IsError      bool       `json:"is_error"`
+	ApplyCount   int        `json:"apply_count"`
+	RetryCount   int        `json:"retry_count"`
+	LineNumber   int        `json:"line_number"`
+	StartTime    *time.Time `json:"start_time,omitempty"`
+	EndTime      *time.Time `json:"end_time,omitempty"`
+	ExitCode     *int       `json:"exit_code,omitempty"`
+}
+
+// ToActionComputerStatuses converts ActionResultsXML to one ActionComputerStatus per computer,
+// or to a single action-level status if no computer reported a result yet
+func (ar *ActionResultsXML) ToActionComputerStatuses() []ActionComputerStatus {
+	if len(ar.Computers) == 0 {
+		return []ActionComputerStatus{{
+			Resource:     ar.Resource,
+			ActionID:     ar.ActionID,
+			ActionStatus: ar.Status,
+			DateIssued:   parseTimeFromString(ar.DateIssued),
+		}}
+	}
+
+	statuses := make([]ActionComputerStatus, 0, len(ar.Computers))
+	for _, computer := range ar.Computers {
+		statuses = append(statuses, ActionComputerStatus{
+			Resource:     ar.Resource,
+			ActionID:     ar.ActionID,
+			ActionStatus: ar.Status,
+			DateIssued:   parseTimeFromString(ar.DateIssued),
+			ComputerID:   computer.ID,
+			ComputerName: computer.Name,
+			Status:       computer.Status,
+			State:        computer.State.Value,
+			IsError:      computer.State.IsError != 0,
+			ApplyCount:   computer.ApplyCount,
+			RetryCount:   computer.RetryCount,
+			LineNumber:   computer.LineNumber,
+			StartTime:    parseTimeFromString(computer.StartTime),
+			EndTime:      parseTimeFromString(computer.EndTime),
+			ExitCode:     computer.ExitCode,
+		})
+	}
+	return statuses
+}
+
+// HasComputer reports whether the status is the result of the action on a computer,
+// rather than the action-level status of an action without computer results
+func (s *ActionComputerStatus) HasComputer() bool {
+	return s.ComputerID != 0
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+)
+
+func TestActionResultsToActionComputerStatuses(t *testing.T) {
+	tests := []struct {
+		name          string
+		xml           string
+		wantComputers []int
+	}{
+		{
+			name: "computer results",
+			xml: `<BESAPI><ActionResults Resource="r"><ActionID>43</ActionID><Status>Open</Status>
+				<DateIssued>Tue, 15 Oct 2024 10:00:00 +0000</DateIssued>
+				<Computer ID="1" Name="a"><Status>The action executed successfully.</Status><State IsError="0">Executed</State><ApplyCount>1</ApplyCount></Computer>
+				<Computer ID="2" Name="b"><Status>The action failed.</Status><State IsError="1">Failed</State><ExitCode>1</ExitCode></Computer>
+			</ActionResults></BESAPI>`,
+			wantComputers: []int{1, 2},
+		},
+		{
+			name: "no computer results",
+			xml: `<BESAPI><ActionResults Resource="r"><ActionID>43</ActionID><Status>Expired</Status>
+				<DateIssued>Tue, 15 Oct 2024 10:00:00 +0000</DateIssued>
+			</ActionResults></BESAPI>`,
+			wantComputers: []int{0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var response ActionStatusResponse
+			if err := xml.Unmarshal([]byte(tt.xml), &response); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+
+			statuses := response.ActionResults.ToActionComputerStatuses()
+			if len(statuses) != len(tt.wantComputers) {
+				t.Fatalf("got %d statuses, want %d", len(statuses), len(tt.wantComputers))
+			}
+			for i, status := range statuses {
+				if status.ActionID != 43 || status.ActionStatus != response.ActionResults.Status || status.DateIssued == nil {
+					t.Errorf("status %d: got action fields %d %q %v", i, status.ActionID, status.ActionStatus, status.DateIssued)
+				}
+				if status.ComputerID != tt.wantComputers[i] {
+					t.Errorf("status %d: got computer %d, want %d", i, status.ComputerID, tt.wantComputers[i])
+				}
+				if status.HasComputer() != (tt.wantComputers[i] != 0) {
+					t.Errorf("status %d: HasComputer() = %v", i, status.HasComputer())
+				}
+			}
+		})
+	}
+}
+
+func TestActionTargetJSON(t *testing.T) {
+	var target ActionTarget
+	body := `<Target><ComputerID>12</ComputerID><ComputerID>34</ComputerID><ComputerName>a</ComputerName></Target>`
+	if err := xml.Unmarshal([]byte(body), &target); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	data, err := json.Marshal(target)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	// computer_id is kept for queries written before computer_ids
+	want := `{"computer_id":12,"computer_ids":[12,34],"computer_names":["a"]}`
+	if string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+}
@@ -25,6 +25,8 @@ type Analysis struct {
 	Delay             string             `json:"delay,omitempty"`
 	MIMEFields        []MIMEField        `json:"mime_fields,omitempty"`
 	Properties        []AnalysisProperty `json:"properties,omitempty"`
+	// DetailsFetched is set when the fields returned by AnalysisService.ListDetails were fetched for the whole site
+	DetailsFetched bool `xml:"-" json:"-"`
 }
 
 // AnalysisDetailResponse represents the XML response for analysis detail
@@ -0,0 +1,175 @@
+package model
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// BaselineListResponse represents the XML response for baseline list
+type BaselineListResponse struct {
+	XMLName   xml.Name   `xml:"BESAPI"`
+	Baselines []Baseline `xml:"Baseline"`
+}
+
+// Baseline represents a BigFix baseline
+type Baseline struct {
+	Resource          string              `xml:"Resource,attr" json:"resource"`
+	LastModified      string              `xml:"LastModified,attr" json:"last_modified"`
+	Name              string              `xml:"Name" json:"name"`
+	ID                int                 `xml:"ID" json:"id"`
+	SiteName          string              `json:"site_name,omitempty"`
+	SiteType          string              `json:"site_type,omitempty"`
+	Title             string              `json:"title,omitempty"`
+	Description       string              `json:"description,omitempty"`
+	Relevance         []string            `json:"relevance,omitempty"`
+	Category          string              `json:"category,omitempty"`
+	Source            string              `json:"source,omitempty"`
+	SourceID          string              `json:"source_id,omitempty"`
+	SourceReleaseDate string              `json:"source_release_date,omitempty"`
+	SourceSeverity    string              `json:"source_severity,omitempty"`
+	CVENames          string              `json:"cve_names,omitempty"`
+	Domain            string              `json:"domain,omitempty"`
+	MIMEFields        []MIMEField         `json:"mime_fields,omitempty"`
+	Components        []BaselineComponent `json:"components,omitempty"`
+}
+
+// BaselineDetailResponse represents the XML response for baseline detail
+type BaselineDetailResponse struct {
+	XMLName  xml.Name       `xml:"BES"`
+	Baseline BaselineDetail `xml:"Baseline"`
+}
+
+// BaselineDetail represents detailed baseline information
+type BaselineDetail struct {
+	Title                       string                      `xml:"Title"`
+	Description                 string                      `xml:"Description"`
+	Relevance                   []string                    `xml:"Relevance"`
+	Category                    string                      `xml:"Category"`
+	Source                      string                      `xml:"Source"`
+	SourceID                    string                      `xml:"SourceID"`
+	SourceReleaseDate           string                      `xml:"SourceReleaseDate"`
+	SourceSeverity              string                      `xml:"SourceSeverity"`
+	CVENames                    string                      `xml:"CVENames"`
+	Domain                      string                      `xml:"Domain"`
+	MIMEFields                  []MIMEField                 `xml:"MIMEField"`
+	BaselineComponentCollection BaselineComponentCollection `xml:"BaselineComponentCollection"`
+}
+
+// BaselineComponentCollection represents the component groups of a baseline
+type BaselineComponentCollection struct {
+	Groups []BaselineComponentGroup `xml:"BaselineComponentGroup"`
+}
+
+// BaselineComponentGroup represents a named group of baseline components
+type BaselineComponentGroup struct {
+	Name       string                 `xml:"Name,attr"`
+	Components []BaselineComponentXML `xml:"BaselineComponent"`
+}
+
+// BaselineComponentXML represents a single baseline component
+type BaselineComponentXML struct {
+	Name               string `xml:"Name,attr"`
+	IncludeInRelevance string `xml:"IncludeInRelevance,attr"`
+	SourceSiteURL      string `xml:"SourceSiteURL,attr"`
+	SourceID           string `xml:"SourceID,attr"`
+	ActionName         string `xml:"ActionName,attr"`
+	ActionScript       string `xml:"ActionScript"`
+	SuccessCriteria    string `xml:"SuccessCriteria"`
+	Relevance          string `xml:"Relevance"`
+}
+
+// BaselineComponent represents a baseline component for API return
+type BaselineComponent struct {
+	GroupName          string `json:"group_name,omitempty"`
+	Name               string `json:"name"`
+	SourceSiteURL      string `json:"source_site_url,omitempty"`
+	SourceID           string `json:"source_id,omitempty"`
+	ActionName         string `json:"action_name,omitempty"`
+	IncludeInRelevance bool   `json:"include_in_relevance"`
+	IsSynced           *bool  `json:"is_synced,omitempty"` // Set by BaselineService.SyncComponents, nil when the source is unknown
+	ActionScript       string `json:"action_script,omitempty"`
+	SuccessCriteria    string `json:"success_criteria,omitempty"`
+	Relevance          string `json:"relevance,omitempty"`
+}
+
+// ToBaseline converts BaselineDetail to Baseline model
+func (bd *BaselineDetail) ToBaseline(id int, resource, siteName, siteType string) *Baseline {
+	baseline := &Baseline{
+		ID:                id,
+		Resource:          resource,
+		Name:              bd.Title,
+		SiteName:          siteName,
+		SiteType:          siteType,
+		Title:             bd.Title,
+		Description:       bd.Description,
+		Relevance:         bd.Relevance,
+		Category:          bd.Category,
+		Source:            bd.Source,
+		SourceID:          bd.SourceID,
+		SourceReleaseDate: bd.SourceReleaseDate,
+		SourceSeverity:    bd.SourceSeverity,
+		CVENames:          bd.CVENames,
+		Domain:            bd.Domain,
+		MIMEFields:        bd.MIMEFields,
+	}
+
+	for _, group := range bd.BaselineComponentCollection.Groups {
+		for _, component := range group.Components {
+			baseline.Components = append(baseline.Components, BaselineComponent{
+				GroupName:          group.Name,
+				Name:               component.Name,
+				SourceSiteURL:      component.SourceSiteURL,
+				SourceID:           component.SourceID,
+				ActionName:         component.ActionName,
+				IncludeInRelevance: strings.EqualFold(component.IncludeInRelevance, "true"),
+				ActionScript:       component.ActionScript,
+				SuccessCriteria:    component.SuccessCriteria,
+				Relevance:          component.Relevance,
+			})
+		}
+	}
+
+	return baseline
+}
+
+// BaselineSourceResponse represents the XML response for the fixlet or task a baseline component was copied from
+type BaselineSourceResponse struct {
+	XMLName xml.Name      `xml:"BES"`
+	Fixlet  *FixletDetail `xml:"Fixlet"`
+	Task    *FixletDetail `xml:"Task"`
+}
+
+// Actions returns the default action and the other actions of the source content
+func (r *BaselineSourceResponse) Actions() []FixletAction {
+	detail := r.Fixlet
+	if detail == nil {
+		detail = r.Task
+	}
+	if detail == nil {
+		return nil
+	}
+
+	var actions []FixletAction
+	if detail.DefaultAction != nil {
+		actions = append(actions, *detail.DefaultAction)
+	}
+	return append(actions, detail.Actions...)
+}
+
+// IsSyncedWith reports whether the component still has the action script and success criteria
+// of the action with the same name in its source content
+func (bc *BaselineComponent) IsSyncedWith(actions []FixletAction) bool {
+	for _, action := range actions {
+		if action.ID != bc.ActionName {
+			continue
+		}
+		return normalizeScript(action.ActionScript) == normalizeScript(bc.ActionScript) &&
+			normalizeScript(action.SuccessCriteria) == normalizeScript(bc.SuccessCriteria)
+	}
+	return false
+}
+
+// Helper function to compare scripts regardless of line endings and surrounding whitespace
+func normalizeScript(script string) string {
+	return strings.TrimSpace(strings.ReplaceAll(script, "\r\n", "\n"))
+}
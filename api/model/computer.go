@@ -22,6 +22,8 @@ type ComputerListXML struct {
 	LastReportTime string `xml:"LastReportTime"`
 	CPU            string `xml:"CPU"`
 	IPAddress      string `xml:"IPAddress"`
+	// Properties holds the retrieved properties requested through the fields parameter
+	Properties []Property `xml:"Property"`
 }
 
 // ComputerXMLResponse represents the XML response structure for single computer details
@@ -80,100 +82,98 @@ type NameValue struct {
 // ToComputer converts ComputerXML response to Computer model
 func (cx *ComputerXML) ToComputer() (*Computer, error) {
 	computer := &Computer{
-		Resource:   cx.Resource,
-		Properties: cx.Properties,
+		Resource: cx.Resource,
 	}
+	computer.setProperties(cx.Properties)
 
-	// Time parsing layouts
-	layouts := []string{
-		"Mon, 2 Jan 2006 15:04:05 -0700",  // RFC1123Z format with numeric timezone
-		"Mon, 02 Jan 2006 15:04:05 -0700", // RFC1123Z with zero-padded day
-		time.RFC1123Z,
-		time.RFC1123,
-		"Mon, 2 Jan 2006 15:04:05 MST",
-		time.RFC3339,
-		time.RFC3339Nano,
-		time.ANSIC,
-	}
+	return computer, nil
+}
+
+// setProperties stores the retrieved properties of the computer and parses them into structured fields
+func (c *Computer) setProperties(properties []Property) {
+	c.Properties = properties
 
-	// Parse properties into structured fields
-	for _, prop := range cx.Properties {
+	for _, prop := range properties {
 		switch prop.Name {
 		case "ID":
 			if id := parseIntFromString(prop.Value); id != 0 {
-				computer.ID = id
+				c.ID = id
 			}
 		case "Computer Name":
-			computer.Name = prop.Value
+			c.Name = prop.Value
 		case "OS":
-			computer.OS = prop.Value
+			c.OS = prop.Value
 		case "Last Report Time":
-			for _, layout := range layouts {
-				if parsed, err := time.Parse(layout, prop.Value); err == nil {
-					computer.LastReportTime = &parsed
-					break
-				}
-			}
+			c.LastReportTime = parseTimeFromString(prop.Value)
 		case "CPU":
-			computer.CPU = prop.Value
+			c.CPU = prop.Value
 		case "IP Address":
-			computer.IPAddress = prop.Value
+			c.IPAddress = prop.Value
 		case "IPv6 Address":
-			computer.IPv6Address = prop.Value
+			c.IPv6Address = prop.Value
 		case "DNS Name":
-			computer.DNSName = prop.Value
+			c.DNSName = prop.Value
 		case "MAC Address":
-			computer.MACAddress = prop.Value
+			c.MACAddress = prop.Value
 		case "OS Family":
-			computer.OSFamily = prop.Value
+			c.OSFamily = prop.Value
 		case "OS Name":
-			computer.OSName = prop.Value
+			c.OSName = prop.Value
 		case "OS Version":
-			computer.OSVersion = prop.Value
+			c.OSVersion = prop.Value
 		case "User Name":
-			computer.UserName = prop.Value
+			c.UserName = prop.Value
 		case "RAM":
-			computer.RAM = prop.Value
+			c.RAM = prop.Value
 		case "Locked":
-			computer.Locked = prop.Value
+			c.Locked = prop.Value
 		case "BES Relay Selection Method":
-			computer.BESRelaySelection = prop.Value
+			c.BESRelaySelection = prop.Value
 		case "Relay":
-			computer.Relay = prop.Value
+			c.Relay = prop.Value
 		case "Distance to BES Relay":
-			computer.DistanceToBESRelay = prop.Value
+			c.DistanceToBESRelay = prop.Value
 		case "Agent Type":
-			computer.AgentType = prop.Value
+			c.AgentType = prop.Value
 		case "Device Type":
-			computer.DeviceType = prop.Value
+			c.DeviceType = prop.Value
 		case "Agent Version":
-			computer.AgentVersion = prop.Value
+			c.AgentVersion = prop.Value
 		case "Computer Type":
-			computer.ComputerType = prop.Value
+			c.ComputerType = prop.Value
 		case "License Type":
-			computer.LicenseType = prop.Value
+			c.LicenseType = prop.Value
 		case "Free Space on System Drive":
-			computer.FreeSpaceOnSystem = prop.Value
+			c.FreeSpaceOnSystem = prop.Value
 		case "Total Size of System Drive":
-			computer.TotalSizeOfSystem = prop.Value
+			c.TotalSizeOfSystem = prop.Value
 		case "BIOS":
-			computer.BIOS = prop.Value
+			c.BIOS = prop.Value
 		case "Subnet Address":
-			computer.SubnetAddress = prop.Value
+			c.SubnetAddress = prop.Value
 		case "Client Settings":
 			// Parse client setting value to extract name=value pairs
 			if name, value := parseClientSetting(prop.Value); name != "" {
-				computer.ClientSettings = append(computer.ClientSettings, NameValue{
+				c.ClientSettings = append(c.ClientSettings, NameValue{
 					Name:  name,
 					Value: value,
 				})
 			}
 		case "Subscribed Sites":
-			computer.SubscribedSites = append(computer.SubscribedSites, prop.Value)
+			c.SubscribedSites = append(c.SubscribedSites, prop.Value)
 		}
 	}
+}
 
-	return computer, nil
+// PropertyValues returns the values the computer reports for the property with the given name
+func (c *Computer) PropertyValues(name string) []string {
+	var values []string
+	for _, prop := range c.Properties {
+		if prop.Name == name {
+			values = append(values, prop.Value)
+		}
+	}
+	return values
 }
 
 // Helper function to parse integer from string
@@ -184,6 +184,33 @@ func parseIntFromString(s string) int {
 	return 0
 }
 
+// Time parsing layouts used by the BigFix API
+var timeLayouts = []string{
+	"Mon, 2 Jan 2006 15:04:05 -0700",  // RFC1123Z format with numeric timezone
+	"Mon, 02 Jan 2006 15:04:05 -0700", // RFC1123Z with zero-padded day
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	time.RFC3339,
+	time.RFC3339Nano,
+	time.ANSIC,
+}
+
+// ParseTime parses a timestamp in one of the formats returned by the BigFix API, returns nil if the value cannot be parsed
+func ParseTime(s string) *time.Time {
+	return parseTimeFromString(s)
+}
+
+// Helper function to parse a BigFix timestamp, returns nil if the value cannot be parsed
+func parseTimeFromString(s string) *time.Time {
+	for _, layout := range timeLayouts {
+		if parsed, err := time.Parse(layout, s); err == nil {
+			return &parsed
+		}
+	}
+	return nil
+}
+
 // Helper function to parse client settings in format "name=value"
 func parseClientSetting(setting string) (name, value string) {
 	parts := strings.SplitN(setting, "=", 2)
@@ -206,22 +233,10 @@ func (cl *ComputerListXML) ToComputer() (*Computer, error) {
 	}
 
 	// Parse LastReportTime with multiple layouts
-	layouts := []string{
-		"Mon, 2 Jan 2006 15:04:05 -0700",  // RFC1123Z format with numeric timezone
-		"Mon, 02 Jan 2006 15:04:05 -0700", // RFC1123Z with zero-padded day
-		time.RFC1123Z,
-		time.RFC1123,
-		"Mon, 2 Jan 2006 15:04:05 MST",
-		time.RFC3339,
-		time.RFC3339Nano,
-		time.ANSIC,
-	}
+	computer.LastReportTime = parseTimeFromString(cl.LastReportTime)
 
-	for _, layout := range layouts {
-		if parsed, err := time.Parse(layout, cl.LastReportTime); err == nil {
-			computer.LastReportTime = &parsed
-			break
-		}
+	if len(cl.Properties) > 0 {
+		computer.setProperties(cl.Properties)
 	}
 
 	return computer, nil
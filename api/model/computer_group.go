@@ -0,0 +1,151 @@
+package model
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// ComputerGroup represents a BigFix computer group (list response)
+type ComputerGroup struct {
+	Resource           string                   `xml:"Resource,attr" json:"resource"`
+	LastModified       string                   `xml:"LastModified,attr" json:"last_modified,omitempty"`
+	Name               string                   `xml:"Name" json:"name"`
+	ID                 int                      `xml:"ID" json:"id"`
+	SiteName           string                   `json:"site_name,omitempty"`
+	SiteType           string                   `json:"site_type,omitempty"`
+	Title              string                   `json:"title,omitempty"`
+	Domain             string                   `json:"domain,omitempty"`
+	Type               string                   `json:"type,omitempty"` // "automatic" or "manual"
+	EvaluateOnClient   bool                     `json:"evaluate_on_client,omitempty"`
+	JoinByIntersection bool                     `json:"join_by_intersection,omitempty"`
+	Criteria           []ComputerGroupCriterion `json:"criteria,omitempty"`
+}
+
+// ComputerGroupDetailResponse represents the XML response for computer group detail
+type ComputerGroupDetailResponse struct {
+	XMLName             xml.Name                   `xml:"BES"`
+	ComputerGroup       *ComputerGroupDetail       `xml:"ComputerGroup,omitempty"`
+	ManualComputerGroup *ManualComputerGroupDetail `xml:"ManualComputerGroup,omitempty"`
+}
+
+// ComputerGroupDetail represents detailed automatic computer group information
+type ComputerGroupDetail struct {
+	Title               string                          `xml:"Title"`
+	Domain              string                          `xml:"Domain"`
+	JoinByIntersection  bool                            `xml:"JoinByIntersection"`
+	IsDynamic           *bool                           `xml:"IsDynamic"`
+	EvaluateOnClient    bool                            `xml:"EvaluateOnClient"`
+	PropertyReferences  []SearchComponentPropertyRef    `xml:"SearchComponentPropertyReference"`
+	RelevanceComponents []SearchComponentRelevance      `xml:"SearchComponentRelevance"`
+	GroupReferences     []SearchComponentGroupReference `xml:"SearchComponentGroupReference"`
+}
+
+// ManualComputerGroupDetail represents detailed manual computer group information
+type ManualComputerGroupDetail struct {
+	Title            string `xml:"Title"`
+	Domain           string `xml:"Domain"`
+	EvaluateOnClient bool   `xml:"EvaluateOnClient"`
+}
+
+// SearchComponentPropertyRef represents a membership criterion based on a property value
+type SearchComponentPropertyRef struct {
+	PropertyName string `xml:"PropertyName,attr"`
+	Comparison   string `xml:"Comparison,attr"`
+	SearchText   string `xml:"SearchText"`
+	Relevance    string `xml:"Relevance"`
+}
+
+// SearchComponentRelevance represents a membership criterion based on a relevance expression
+type SearchComponentRelevance struct {
+	Comparison string `xml:"Comparison,attr"`
+	Relevance  string `xml:"Relevance"`
+}
+
+// SearchComponentGroupReference represents a membership criterion based on another group
+type SearchComponentGroupReference struct {
+	GroupName  string `xml:"GroupName,attr"`
+	Comparison string `xml:"Comparison,attr"`
+}
+
+// ComputerGroupCriterion represents a single membership criterion of a computer group
+type ComputerGroupCriterion struct {
+	Type         string `json:"type"` // "property", "relevance" or "group"
+	Comparison   string `json:"comparison,omitempty"`
+	PropertyName string `json:"property_name,omitempty"`
+	GroupName    string `json:"group_name,omitempty"`
+	SearchText   string `json:"search_text,omitempty"`
+	Relevance    string `json:"relevance,omitempty"`
+}
+
+// ToComputerGroup converts ComputerGroupDetailResponse to ComputerGroup model
+func (cgr *ComputerGroupDetailResponse) ToComputerGroup(id int, resource, siteName, siteType string) *ComputerGroup {
+	group := &ComputerGroup{
+		ID:       id,
+		Resource: resource,
+		SiteName: siteName,
+		SiteType: siteType,
+	}
+
+	if manual := cgr.ManualComputerGroup; manual != nil {
+		group.Name = manual.Title
+		group.Title = manual.Title
+		group.Domain = manual.Domain
+		group.Type = "manual"
+		group.EvaluateOnClient = manual.EvaluateOnClient
+		return group
+	}
+
+	detail := cgr.ComputerGroup
+	if detail == nil {
+		return group
+	}
+
+	group.Name = detail.Title
+	group.Title = detail.Title
+	group.Domain = detail.Domain
+	group.EvaluateOnClient = detail.EvaluateOnClient
+	group.JoinByIntersection = detail.JoinByIntersection
+
+	// Groups explicitly marked as not dynamic are manual groups
+	group.Type = "automatic"
+	if detail.IsDynamic != nil && !*detail.IsDynamic {
+		group.Type = "manual"
+	}
+
+	for _, ref := range detail.PropertyReferences {
+		group.Criteria = append(group.Criteria, ComputerGroupCriterion{
+			Type:         "property",
+			Comparison:   ref.Comparison,
+			PropertyName: ref.PropertyName,
+			SearchText:   ref.SearchText,
+			Relevance:    ref.Relevance,
+		})
+	}
+	for _, rel := range detail.RelevanceComponents {
+		group.Criteria = append(group.Criteria, ComputerGroupCriterion{
+			Type:       "relevance",
+			Comparison: rel.Comparison,
+			Relevance:  rel.Relevance,
+		})
+	}
+	for _, ref := range detail.GroupReferences {
+		group.Criteria = append(group.Criteria, ComputerGroupCriterion{
+			Type:       "group",
+			Comparison: ref.Comparison,
+			GroupName:  ref.GroupName,
+		})
+	}
+
+	return group
+}
+
+// ComputerGroupMember represents a computer that is a member of a computer group
+type ComputerGroupMember struct {
+	SiteName       string     `json:"site_name"`
+	SiteType       string     `json:"site_type"`
+	GroupID        int        `json:"group_id"`
+	GroupName      string     `json:"group_name,omitempty"`
+	ComputerID     int        `json:"computer_id"`
+	Resource       string     `json:"resource,omitempty"`
+	LastReportTime *time.Time `json:"last_report_time,omitempty"`
+}
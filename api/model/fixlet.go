@@ -30,6 +30,8 @@ type Fixlet struct {
 	Delay             string         `json:"delay,omitempty"`
 	DefaultAction     *FixletAction  `json:"default_action,omitempty"`
 	Actions           []FixletAction `json:"actions,omitempty"`
+	// DetailsFetched is set when the fields returned by FixletService.ListDetails were fetched for the whole site
+	DetailsFetched bool `xml:"-" json:"-"`
 }
 
 // FixletDetailResponse represents the XML response for fixlet detail
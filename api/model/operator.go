@@ -0,0 +1,156 @@
+package model
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// OperatorListResponse represents the XML response for operator list
+type OperatorListResponse struct {
+	XMLName   xml.Name      `xml:"BESAPI"`
+	Operators []OperatorXML `xml:"Operator"`
+}
+
+// OperatorDetailResponse represents the XML response for operator detail
+type OperatorDetailResponse struct {
+	XMLName  xml.Name    `xml:"BESAPI"`
+	Operator OperatorXML `xml:"Operator"`
+}
+
+// OperatorXML represents the XML structure of a BigFix operator
+type OperatorXML struct {
+	Resource                      string          `xml:"Resource,attr"`
+	Name                          string          `xml:"Name"`
+	ID                            int             `xml:"ID"`
+	LDAPServerID                  string          `xml:"LDAPServerID"`
+	LDAPDN                        string          `xml:"LDAPDN"`
+	LastLoginTime                 string          `xml:"LastLoginTime"`
+	MasterOperator                bool            `xml:"MasterOperator"`
+	CustomContent                 bool            `xml:"CustomContent"`
+	ShowOtherActions              bool            `xml:"ShowOtherActions"`
+	StopOtherActions              bool            `xml:"StopOtherActions"`
+	CanCreateActions              bool            `xml:"CanCreateActions"`
+	PostActionBehaviorPrivilege   string          `xml:"PostActionBehaviorPrivilege"`
+	ActionScriptCommandsPrivilege string          `xml:"ActionScriptCommandsPrivilege"`
+	CanSendMultipleRefresh        bool            `xml:"CanSendMultipleRefresh"`
+	CanSubmitQueries              bool            `xml:"CanSubmitQueries"`
+	CanLock                       bool            `xml:"CanLock"`
+	UnmanagedAssetPrivilege       string          `xml:"UnmanagedAssetPrivilege"`
+	InterfaceLogins               InterfaceLogins `xml:"InterfaceLogins"`
+}
+
+// Operator represents a BigFix console operator for API return
+type Operator struct {
+	Resource                      string          `json:"resource,omitempty"`
+	Name                          string          `json:"name"`
+	ID                            int             `json:"id,omitempty"`
+	LDAPServerID                  string          `json:"ldap_server_id,omitempty"`
+	LDAPDN                        string          `json:"ldap_dn,omitempty"`
+	LastLoginTime                 *time.Time      `json:"last_login_time,omitempty"`
+	MasterOperator                bool            `json:"master_operator"`
+	CustomContent                 bool            `json:"custom_content"`
+	ShowOtherActions              bool            `json:"show_other_actions"`
+	StopOtherActions              bool            `json:"stop_other_actions"`
+	CanCreateActions              bool            `json:"can_create_actions"`
+	PostActionBehaviorPrivilege   string          `json:"post_action_behavior_privilege,omitempty"`
+	ActionScriptCommandsPrivilege string          `json:"action_script_commands_privilege,omitempty"`
+	CanSendMultipleRefresh        bool            `json:"can_send_multiple_refresh"`
+	CanSubmitQueries              bool            `json:"can_submit_queries"`
+	CanLock                       bool            `json:"can_lock"`
+	UnmanagedAssetPrivilege       string          `json:"unmanaged_asset_privilege,omitempty"`
+	InterfaceLogins               InterfaceLogins `json:"interface_logins"`
+}
+
+// ToOperator converts OperatorXML to Operator model
+func (ox *OperatorXML) ToOperator() *Operator {
+	return &Operator{
+		Resource:                      ox.Resource,
+		Name:                          ox.Name,
+		ID:                            ox.ID,
+		LDAPServerID:                  ox.LDAPServerID,
+		LDAPDN:                        ox.LDAPDN,
+		LastLoginTime:                 parseTimeFromString(ox.LastLoginTime),
+		MasterOperator:                ox.MasterOperator,
+		CustomContent:                 ox.CustomContent,
+		ShowOtherActions:              ox.ShowOtherActions,
+		StopOtherActions:              ox.StopOtherActions,
+		CanCreateActions:              ox.CanCreateActions,
+		PostActionBehaviorPrivilege:   ox.PostActionBehaviorPrivilege,
+		ActionScriptCommandsPrivilege: ox.ActionScriptCommandsPrivilege,
+		CanSendMultipleRefresh:        ox.CanSendMultipleRefresh,
+		CanSubmitQueries:              ox.CanSubmitQueries,
+		CanLock:                       ox.CanLock,
+		UnmanagedAssetPrivilege:       ox.UnmanagedAssetPrivilege,
+		InterfaceLogins:               ox.InterfaceLogins,
+	}
+}
+
+// OperatorRoleListResponse represents the XML response for the roles of an operator
+type OperatorRoleListResponse struct {
+	XMLName xml.Name       `xml:"BESAPI"`
+	Roles   []OperatorRole `xml:"Role"`
+}
+
+// OperatorRole represents a role assigned to an operator
+type OperatorRole struct {
+	Resource string `xml:"Resource,attr" json:"resource,omitempty"`
+	Name     string `xml:"Name" json:"name"`
+	ID       int    `xml:"ID" json:"id"`
+}
+
+// OperatorSiteListResponse represents the XML response for the sites of an operator
+type OperatorSiteListResponse struct {
+	XMLName xml.Name          `xml:"BESAPI"`
+	Sites   []OperatorSiteXML `xml:",any"`
+}
+
+// OperatorSiteXML represents a site element (ExternalSite, OperatorSite, ActionSite, ...) assigned to an operator
+type OperatorSiteXML struct {
+	XMLName    xml.Name
+	Resource   string `xml:"Resource,attr"`
+	Name       string `xml:"Name"`
+	Permission string `xml:"Permission"`
+}
+
+// OperatorSiteAssignment represents a site an operator has access to
+type OperatorSiteAssignment struct {
+	Resource   string `json:"resource,omitempty"`
+	Name       string `json:"name"`
+	Type       string `json:"type"` // "action", "external", "operator", "custom"
+	Permission string `json:"permission,omitempty"`
+}
+
+// ToOperatorSiteAssignment converts OperatorSiteXML to OperatorSiteAssignment model
+func (osx *OperatorSiteXML) ToOperatorSiteAssignment() *OperatorSiteAssignment {
+	var siteType string
+	switch osx.XMLName.Local {
+	case "ExternalSite":
+		siteType = "external"
+	case "OperatorSite":
+		siteType = "operator"
+	case "ActionSite":
+		siteType = "action"
+	case "CustomSite":
+		siteType = "custom"
+	}
+
+	return &OperatorSiteAssignment{
+		Resource:   osx.Resource,
+		Name:       osx.Name,
+		Type:       siteType,
+		Permission: osx.Permission,
+	}
+}
+
+// OperatorComputerListResponse represents the XML response for the computers an operator administers
+type OperatorComputerListResponse struct {
+	XMLName   xml.Name          `xml:"BESAPI"`
+	Computers []ComputerListXML `xml:"Computer"`
+}
+
+// OperatorComputer represents a computer administered by an operator
+type OperatorComputer struct {
+	Resource       string     `json:"resource,omitempty"`
+	ID             int        `json:"id"`
+	LastReportTime *time.Time `json:"last_report_time,omitempty"`
+}
@@ -0,0 +1,136 @@
+package model
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// QueryResponse represents the XML response for a session relevance query
+type QueryResponse struct {
+	XMLName xml.Name `xml:"BESAPI"`
+	Query   QueryXML `xml:"Query"`
+}
+
+// QueryXML represents the Query element of a session relevance response
+type QueryXML struct {
+	Resource   string          `xml:"Resource,attr"`
+	Result     QueryResultXML  `xml:"Result"`
+	Error      string          `xml:"Error"`
+	Evaluation QueryEvaluation `xml:"Evaluation"`
+}
+
+// QueryResultXML holds the Answer and Tuple elements of a query result in document order
+type QueryResultXML struct {
+	Items []QueryItemXML `xml:",any"`
+}
+
+// QueryItemXML represents either a single Answer or a Tuple of Answers and nested Tuples
+type QueryItemXML struct {
+	XMLName xml.Name
+	Type    string         `xml:"type,attr"`
+	Value   string         `xml:",chardata"`
+	Items   []QueryItemXML `xml:",any"`
+}
+
+// QueryAnswer represents a single typed answer value, or a nested tuple of values
+type QueryAnswer struct {
+	Type  string `xml:"type,attr" json:"type,omitempty"`
+	Value string `xml:",chardata" json:"value"`
+	// Values holds the items of a nested tuple
+	Values []QueryAnswer `json:"values,omitempty"`
+}
+
+// QueryEvaluation represents the evaluation information of a query
+type QueryEvaluation struct {
+	Time      string `xml:"Time" json:"time,omitempty"`
+	Plurality string `xml:"Plurality" json:"plurality,omitempty"`
+}
+
+// Query represents the result of a session relevance query for API return
+type Query struct {
+	Resource       string        `json:"resource,omitempty"`
+	Relevance      string        `json:"relevance"`
+	Results        []QueryResult `json:"results,omitempty"`
+	Error          string        `json:"error,omitempty"`
+	EvaluationTime string        `json:"evaluation_time,omitempty"`
+	Plurality      string        `json:"plurality,omitempty"`
+}
+
+// QueryResult represents a single answer or tuple returned by a query
+type QueryResult struct {
+	TupleIndex int           `json:"tuple_index"`
+	Answer     string        `json:"answer"`
+	Type       string        `json:"type,omitempty"`
+	Values     []QueryAnswer `json:"values"`
+}
+
+// ToQuery converts QueryXML to Query model
+func (qx *QueryXML) ToQuery(relevance string) *Query {
+	query := &Query{
+		Resource:       qx.Resource,
+		Relevance:      relevance,
+		Error:          strings.TrimSpace(qx.Error),
+		EvaluationTime: qx.Evaluation.Time,
+		Plurality:      qx.Evaluation.Plurality,
+	}
+
+	for _, item := range qx.Result.Items {
+		if item.IsResult() {
+			query.Results = append(query.Results, item.ToResult(len(query.Results)))
+		}
+	}
+
+	return query
+}
+
+// IsResult reports whether the item is an Answer or a Tuple, as opposed to other elements of a query result
+func (qi *QueryItemXML) IsResult() bool {
+	return qi.XMLName.Local == "Answer" || qi.XMLName.Local == "Tuple"
+}
+
+// ToResult converts an Answer or a Tuple of a query result to the QueryResult at the given index
+func (qi *QueryItemXML) ToResult(index int) QueryResult {
+	answer := qi.toAnswer()
+	result := QueryResult{
+		TupleIndex: index,
+		Answer:     answer.Value,
+		Type:       answer.Type,
+		Values:     answer.Values,
+	}
+	if qi.XMLName.Local == "Answer" {
+		result.Values = []QueryAnswer{answer}
+	}
+	return result
+}
+
+// toAnswer converts an Answer or a Tuple, which may contain nested Tuples, to a QueryAnswer
+func (qi *QueryItemXML) toAnswer() QueryAnswer {
+	if qi.XMLName.Local != "Tuple" {
+		return QueryAnswer{Type: qi.Type, Value: qi.Value}
+	}
+
+	answer := QueryAnswer{Values: []QueryAnswer{}}
+	values := make([]string, 0, len(qi.Items))
+	types := make([]string, 0, len(qi.Items))
+	for _, item := range qi.Items {
+		if !item.IsResult() {
+			continue
+		}
+		child := item.toAnswer()
+		answer.Values = append(answer.Values, child)
+
+		// Nested tuples are parenthesized, e.g. "a, ( b, 1 )"
+		if item.XMLName.Local == "Tuple" {
+			values = append(values, "( "+child.Value+" )")
+		} else {
+			values = append(values, child.Value)
+		}
+		types = append(types, child.Type)
+	}
+
+	// Mirror the way BigFix presents tuples, e.g. "a, 1" of type "( string, integer )"
+	answer.Value = strings.Join(values, ", ")
+	answer.Type = "( " + strings.Join(types, ", ") + " )"
+
+	return answer
+}
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestQueryXMLToQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		xml  string
+		want []QueryResult
+	}{
+		{
+			name: "single answers",
+			xml: `<BESAPI><Query Resource="r"><Result>
+				<Answer type="string">a</Answer>
+				<Answer type="integer">1</Answer>
+			</Result></Query></BESAPI>`,
+			want: []QueryResult{
+				{TupleIndex: 0, Answer: "a", Type: "string", Values: []QueryAnswer{{Type: "string", Value: "a"}}},
+				{TupleIndex: 1, Answer: "1", Type: "integer", Values: []QueryAnswer{{Type: "integer", Value: "1"}}},
+			},
+		},
+		{
+			name: "flat tuple",
+			xml: `<BESAPI><Query><Result>
+				<Tuple><Answer type="string">a</Answer><Answer type="integer">1</Answer></Tuple>
+			</Result></Query></BESAPI>`,
+			want: []QueryResult{
+				{
+					TupleIndex: 0,
+					Answer:     "a, 1",
+					Type:       "( string, integer )",
+					Values:     []QueryAnswer{{Type: "string", Value: "a"}, {Type: "integer", Value: "1"}},
+				},
+			},
+		},
+		{
+			name: "nested tuple",
+			xml: `<BESAPI><Query><Result>
+				<Tuple>
+					<Answer type="string">a</Answer>
+					<Tuple><Answer type="string">b</Answer><Answer type="boolean">True</Answer></Tuple>
+					<Answer type="integer">2</Answer>
+				</Tuple>
+			</Result></Query></BESAPI>`,
+			want: []QueryResult{
+				{
+					TupleIndex: 0,
+					Answer:     "a, ( b, True ), 2",
+					Type:       "( string, ( string, boolean ), integer )",
+					Values: []QueryAnswer{
+						{Type: "string", Value: "a"},
+						{
+							Type:   "( string, boolean )",
+							Value:  "b, True",
+							Values: []QueryAnswer{{Type: "string", Value: "b"}, {Type: "boolean", Value: "True"}},
+						},
+						{Type: "integer", Value: "2"},
+					},
+				},
+			},
+		},
+		{
+			name: "empty result",
+			xml:  `<BESAPI><Query><Result></Result></Query></BESAPI>`,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var response QueryResponse
+			if err := xml.Unmarshal([]byte(tt.xml), &response); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+
+			query := response.Query.ToQuery("relevance")
+			if !reflect.DeepEqual(query.Results, tt.want) {
+				t.Errorf("got %+v, want %+v", query.Results, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryXMLToQueryError(t *testing.T) {
+	response := QueryResponse{}
+	body := `<BESAPI><Query><Result></Result><Error>
+		The operator "foo" is not defined.
+	</Error><Evaluation><Time>1ms</Time><Plurality>Singular</Plurality></Evaluation></Query></BESAPI>`
+	if err := xml.Unmarshal([]byte(body), &response); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	query := response.Query.ToQuery("foo")
+	if query.Error != `The operator "foo" is not defined.` {
+		t.Errorf("got error %q", query.Error)
+	}
+	if query.EvaluationTime != "1ms" || query.Plurality != "Singular" {
+		t.Errorf("got evaluation %q %q", query.EvaluationTime, query.Plurality)
+	}
+}
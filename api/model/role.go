@@ -10,22 +10,27 @@ type RoleListResponse struct {
 
 // Role represents a BigFix role
 type Role struct {
-	Resource                      string          `xml:"Resource,attr" json:"resource"`
-	LastModified                  string          `xml:"LastModified,attr" json:"last_modified,omitempty"`
-	Name                          string          `xml:"Name" json:"name"`
-	ID                            int             `xml:"ID" json:"id"`
-	MasterOperator                int             `xml:"MasterOperator" json:"master_operator"`
-	CustomContent                 int             `xml:"CustomContent" json:"custom_content"`
-	ShowOtherActions              int             `xml:"ShowOtherActions" json:"show_other_actions"`
-	StopOtherActions              int             `xml:"StopOtherActions" json:"stop_other_actions"`
-	CanCreateActions              int             `xml:"CanCreateActions" json:"can_create_actions"`
-	PostActionBehaviorPrivilege   string          `xml:"PostActionBehaviorPrivilege" json:"post_action_behavior_privilege"`
-	ActionScriptCommandsPrivilege string          `xml:"ActionScriptCommandsPrivilege" json:"action_script_commands_privilege"`
-	CanSendMultipleRefresh        int             `xml:"CanSendMultipleRefresh" json:"can_send_multiple_refresh"`
-	CanSubmitQueries              int             `xml:"CanSubmitQueries" json:"can_submit_queries"`
-	CanLock                       int             `xml:"CanLock" json:"can_lock"`
-	UnmanagedAssetPrivilege       string          `xml:"UnmanagedAssetPrivilege" json:"unmanaged_asset_privilege"`
-	InterfaceLogins               InterfaceLogins `xml:"InterfaceLogins" json:"interface_logins"`
+	Resource                      string                  `xml:"Resource,attr" json:"resource"`
+	LastModified                  string                  `xml:"LastModified,attr" json:"last_modified,omitempty"`
+	Name                          string                  `xml:"Name" json:"name"`
+	ID                            int                     `xml:"ID" json:"id"`
+	MasterOperator                int                     `xml:"MasterOperator" json:"master_operator"`
+	CustomContent                 int                     `xml:"CustomContent" json:"custom_content"`
+	ShowOtherActions              int                     `xml:"ShowOtherActions" json:"show_other_actions"`
+	StopOtherActions              int                     `xml:"StopOtherActions" json:"stop_other_actions"`
+	CanCreateActions              int                     `xml:"CanCreateActions" json:"can_create_actions"`
+	PostActionBehaviorPrivilege   string                  `xml:"PostActionBehaviorPrivilege" json:"post_action_behavior_privilege"`
+	ActionScriptCommandsPrivilege string                  `xml:"ActionScriptCommandsPrivilege" json:"action_script_commands_privilege"`
+	CanSendMultipleRefresh        int                     `xml:"CanSendMultipleRefresh" json:"can_send_multiple_refresh"`
+	CanSubmitQueries              int                     `xml:"CanSubmitQueries" json:"can_submit_queries"`
+	CanLock                       int                     `xml:"CanLock" json:"can_lock"`
+	UnmanagedAssetPrivilege       string                  `xml:"UnmanagedAssetPrivilege" json:"unmanaged_asset_privilege"`
+	InterfaceLogins               InterfaceLogins         `xml:"InterfaceLogins" json:"interface_logins"`
+	Operators                     []string                `xml:"Operators>Explicit" json:"operators,omitempty"`
+	LDAPAssignments               []RoleLDAPAssignment    `xml:"LDAPAssignments>LDAPAssignment" json:"ldap_assignments,omitempty"`
+	SitesXML                      RoleSitesXML            `xml:"Sites" json:"-"`
+	Sites                         []RoleSitePermission    `json:"sites,omitempty"`
+	ComputerAssignments           RoleComputerAssignments `xml:"ComputerAssignments" json:"computer_assignments"`
 }
 
 // InterfaceLogins represents the interface login permissions for a role
@@ -35,14 +40,88 @@ type InterfaceLogins struct {
 	API     bool `xml:"API" json:"api"`
 }
 
+// RoleLDAPAssignment represents an LDAP user or group assigned to a role
+type RoleLDAPAssignment struct {
+	LDAPServerID string `xml:"LDAPServerID" json:"ldap_server_id,omitempty"`
+	LDAPDN       string `xml:"LDAPDN" json:"ldap_dn"`
+}
+
+// RoleSitesXML holds the site elements (ExternalSite, OperatorSite, ActionSite, CustomSite) of a role
+type RoleSitesXML struct {
+	Sites []RoleSiteXML `xml:",any"`
+}
+
+// RoleSiteXML represents a site element of a role with its permission level
+type RoleSiteXML struct {
+	XMLName    xml.Name
+	Resource   string `xml:"Resource,attr"`
+	Name       string `xml:"Name"`
+	Permission string `xml:"Permission"`
+}
+
+// RoleSitePermission represents the permission a role grants on a site
+type RoleSitePermission struct {
+	Resource   string `json:"resource,omitempty"`
+	Name       string `json:"name"`
+	Type       string `json:"type"` // "action", "external", "operator", "custom"
+	Permission string `json:"permission,omitempty"`
+}
+
+// RoleComputerAssignments represents the computers a role grants access to
+type RoleComputerAssignments struct {
+	ComputerIDs []int                    `xml:"ByComputerID" json:"computer_ids,omitempty"`
+	Groups      []RoleGroupAssignment    `xml:"ByGroup" json:"groups,omitempty"`
+	Properties  *RolePropertyAssignments `xml:"ByRetrievedProperties" json:"properties,omitempty"`
+}
+
+// RoleGroupAssignment represents a computer group assigned to a role
+type RoleGroupAssignment struct {
+	Resource string `xml:"Resource,attr" json:"resource,omitempty"`
+	Name     string `xml:",chardata" json:"name"`
+}
+
+// RolePropertyAssignments represents retrieved property conditions that select the computers of a role
+type RolePropertyAssignments struct {
+	Match      string                   `xml:"Match,attr" json:"match,omitempty"`
+	Conditions []RolePropertyAssignment `xml:"ByRetrievedProperty" json:"conditions,omitempty"`
+}
+
+// RolePropertyAssignment represents a single retrieved property condition
+type RolePropertyAssignment struct {
+	PropertyName string `xml:"PropertyName,attr" json:"property_name"`
+	Comparison   string `xml:"Comparison,attr" json:"comparison,omitempty"`
+	SearchText   string `xml:"SearchText" json:"search_text,omitempty"`
+}
+
 // RoleDetailResponse represents the XML response for role detail
 type RoleDetailResponse struct {
 	XMLName xml.Name `xml:"BESAPI"`
 	Role    Role     `xml:"Role"`
 }
 
-// ToRole converts Role to itself (for consistency with other models)
+// ToRole converts Role to itself (for consistency with other models), resolving the site permissions
 func (r *Role) ToRole() *Role {
+	r.Sites = nil
+	for _, site := range r.SitesXML.Sites {
+		var siteType string
+		switch site.XMLName.Local {
+		case "ExternalSite":
+			siteType = "external"
+		case "OperatorSite":
+			siteType = "operator"
+		case "ActionSite":
+			siteType = "action"
+		case "CustomSite":
+			siteType = "custom"
+		}
+
+		r.Sites = append(r.Sites, RoleSitePermission{
+			Resource:   site.Resource,
+			Name:       site.Name,
+			Type:       siteType,
+			Permission: site.Permission,
+		})
+	}
 	return r
 }
 
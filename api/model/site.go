@@ -10,6 +10,7 @@ type SiteListResponse struct {
 	ExternalSites []ExternalSite `xml:"ExternalSite"`
 	OperatorSites []OperatorSite `xml:"OperatorSite"`
 	ActionSites   []ActionSite   `xml:"ActionSite"`
+	CustomSites   []CustomSite   `xml:"CustomSite"`
 }
 
 // ExternalSite represents an external site in the list response
@@ -36,12 +37,21 @@ type ActionSite struct {
 	GatherURL   string `xml:"GatherURL"`
 }
 
+// CustomSite represents a custom site in the list response
+type CustomSite struct {
+	Resource    string `xml:"Resource,attr"`
+	Name        string `xml:"Name"`
+	DisplayName string `xml:"DisplayName"`
+	GatherURL   string `xml:"GatherURL"`
+}
+
 // SiteDetailResponse represents the XML response structure for single site details
 type SiteDetailResponse struct {
 	XMLName      xml.Name            `xml:"BES"`
 	ActionSite   *ActionSiteDetail   `xml:"ActionSite,omitempty"`
 	ExternalSite *ExternalSiteDetail `xml:"ExternalSite,omitempty"`
 	OperatorSite *OperatorSiteDetail `xml:"OperatorSite,omitempty"`
+	CustomSite   *CustomSiteDetail   `xml:"CustomSite,omitempty"`
 }
 
 // Subscription represents the subscription structure in BigFix XML
@@ -79,13 +89,23 @@ type OperatorSiteDetail struct {
 	GatherURL            string       `xml:"GatherURL"`
 }
 
+// CustomSiteDetail represents detailed custom site information
+type CustomSiteDetail struct {
+	Name                 string       `xml:"Name"`
+	DisplayName          string       `xml:"DisplayName"`
+	Description          string       `xml:"Description"`
+	GlobalReadPermission string       `xml:"GlobalReadPermission"`
+	Subscription         Subscription `xml:"Subscription"`
+	GatherURL            string       `xml:"GatherURL"`
+}
+
 // Site represents a BigFix site for API return
 type Site struct {
 	Resource             string `json:"resource,omitempty"`
 	Name                 string `json:"name"`
 	DisplayName          string `json:"display_name,omitempty"`
 	Description          string `json:"description,omitempty"`
-	Type                 string `json:"type"` // "action", "external", "operator"
+	Type                 string `json:"type"` // "action", "external", "operator", "custom"
 	GlobalReadPermission *bool  `json:"global_read_permission,omitempty"`
 	SubscriptionMode     string `json:"subscription_mode,omitempty"`
 	GatherURL            string `json:"gather_url,omitempty"`
@@ -122,6 +142,16 @@ func (as *ActionSite) ToSite() *Site {
 	}
 }
 
+func (cs *CustomSite) ToSite() *Site {
+	return &Site{
+		Resource:    cs.Resource,
+		Name:        cs.Name,
+		DisplayName: cs.DisplayName,
+		Type:        "custom",
+		GatherURL:   cs.GatherURL,
+	}
+}
+
 // ToSite converts detailed site information to Site model
 func (asd *ActionSiteDetail) ToSite() *Site {
 	globalRead := asd.GlobalReadPermission == "true"
@@ -165,6 +195,20 @@ func (osd *OperatorSiteDetail) ToSite() *Site {
 	}
 }
 
+func (csd *CustomSiteDetail) ToSite() *Site {
+	globalRead := csd.GlobalReadPermission == "true"
+	return &Site{
+		Resource:             "", // Set by calling function
+		Name:                 csd.Name,
+		DisplayName:          csd.DisplayName,
+		Description:          csd.Description,
+		Type:                 "custom",
+		GlobalReadPermission: &globalRead,
+		SubscriptionMode:     csd.Subscription.Mode,
+		GatherURL:            csd.GatherURL,
+	}
+}
+
 // SitePermission represents a site permission
 type SitePermission struct {
 	Resource   string             `xml:"Resource,attr" json:"resource"`
@@ -29,6 +29,8 @@ type Task struct {
 	MIMEFields        []MIMEField  `json:"mime_fields,omitempty"`
 	DefaultAction     *TaskAction  `json:"default_action,omitempty"`
 	Actions           []TaskAction `json:"actions,omitempty"`
+	// DetailsFetched is set when the fields returned by TaskService.ListDetails were fetched for the whole site
+	DetailsFetched bool `xml:"-" json:"-"`
 }
 
 // TaskDetailResponse represents the XML response for task detail
@@ -0,0 +1,241 @@
+package api
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+
+	"github.com/turbot/steampipe-plugin-bigfix/api/model"
+	"resty.dev/v3"
+)
+
+// OperatorService encapsulates the API logic for operator-related operations
+type OperatorService struct {
+	client *Client
+}
+
+// NewOperatorService creates a new OperatorService
+func NewOperatorService(client *Client) *OperatorService {
+	return &OperatorService{
+		client: client,
+	}
+}
+
+// List retrieves all operators
+func (ops *OperatorService) List(ctx context.Context) ([]model.Operator, error) {
+	var operators []model.Operator
+	err := ops.ListFunc(ctx, func(operator model.Operator) bool {
+		operators = append(operators, operator)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ops.client.logger.Debug("API response", "operators", operators)
+
+	return operators, nil
+}
+
+// ListFunc streams all operators to fn as they are decoded from the response, stopping as soon as fn returns false
+func (ops *OperatorService) ListFunc(ctx context.Context, fn func(model.Operator) bool) error {
+	endpoint := "/api/operators"
+
+	// Perform the request with retry logic and limiter tag
+	resp, err := ops.client.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
+		return ops.client.Resty.R().
+			SetContext(ctx).
+			SetHeader("Accept", "application/xml").
+			Get(ops.client.BaseURL + ":" + strconv.Itoa(ops.client.PortNumber) + endpoint)
+	}, "bigfix_operator_list")
+
+	if err != nil {
+		return fmt.Errorf("failed to fetch operators: %w", err)
+	}
+
+	if resp.Body != nil {
+		defer resp.Body.Close()
+	}
+
+	// Decode the operators one at a time, converting each to an Operator model
+	return decodeXMLList(resp.Body, "Operator", func(operatorXML model.OperatorXML) bool {
+		return fn(*operatorXML.ToOperator())
+	})
+}
+
+// Get retrieves a specific operator detail
+func (ops *OperatorService) Get(ctx context.Context, name string) (*model.Operator, error) {
+	endpoint := "/api/operator/" + url.PathEscape(name)
+
+	// Perform the request with retry logic and limiter tag
+	resp, err := ops.client.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
+		return ops.client.Resty.R().
+			SetContext(ctx).
+			SetHeader("Accept", "application/xml").
+			Get(ops.client.BaseURL + ":" + strconv.Itoa(ops.client.PortNumber) + endpoint)
+	}, "bigfix_operator_get")
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch operator %s: %w", name, err)
+	}
+
+	if resp.Body != nil {
+		defer resp.Body.Close()
+	}
+
+	// Read the response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Parse XML for operator detail response
+	var result model.OperatorDetailResponse
+	if err := xml.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse XML response: %w", err)
+	}
+
+	// Convert to Operator model
+	operator := result.Operator.ToOperator()
+	if operator.Resource == "" {
+		operator.Resource = ops.client.BaseURL + ":" + strconv.Itoa(ops.client.PortNumber) + endpoint
+	}
+
+	ops.client.logger.Debug("API response", "operator", operator)
+
+	return operator, nil
+}
+
+// GetRoles retrieves the roles assigned to a specific operator
+func (ops *OperatorService) GetRoles(ctx context.Context, name string) ([]model.OperatorRole, error) {
+	endpoint := "/api/operator/" + url.PathEscape(name) + "/roles"
+
+	// Perform the request with retry logic and limiter tag
+	resp, err := ops.client.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
+		return ops.client.Resty.R().
+			SetContext(ctx).
+			SetHeader("Accept", "application/xml").
+			Get(ops.client.BaseURL + ":" + strconv.Itoa(ops.client.PortNumber) + endpoint)
+	}, "bigfix_operator_roles")
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch roles for operator %s: %w", name, err)
+	}
+
+	if resp.Body != nil {
+		defer resp.Body.Close()
+	}
+
+	// Read the response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Parse XML for operator roles response
+	var result model.OperatorRoleListResponse
+	if err := xml.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse XML response: %w", err)
+	}
+
+	ops.client.logger.Debug("API response", "operator_roles", result.Roles)
+
+	return result.Roles, nil
+}
+
+// GetSites retrieves the sites a specific operator has access to
+func (ops *OperatorService) GetSites(ctx context.Context, name string) ([]model.OperatorSiteAssignment, error) {
+	endpoint := "/api/operator/" + url.PathEscape(name) + "/sites"
+
+	// Perform the request with retry logic and limiter tag
+	resp, err := ops.client.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
+		return ops.client.Resty.R().
+			SetContext(ctx).
+			SetHeader("Accept", "application/xml").
+			Get(ops.client.BaseURL + ":" + strconv.Itoa(ops.client.PortNumber) + endpoint)
+	}, "bigfix_operator_sites")
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sites for operator %s: %w", name, err)
+	}
+
+	if resp.Body != nil {
+		defer resp.Body.Close()
+	}
+
+	// Read the response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Parse XML for operator sites response
+	var result model.OperatorSiteListResponse
+	if err := xml.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse XML response: %w", err)
+	}
+
+	// Convert XML sites to OperatorSiteAssignment models
+	sites := make([]model.OperatorSiteAssignment, 0, len(result.Sites))
+	for _, siteXML := range result.Sites {
+		sites = append(sites, *siteXML.ToOperatorSiteAssignment())
+	}
+
+	ops.client.logger.Debug("API response", "operator_sites", sites)
+
+	return sites, nil
+}
+
+// GetComputers retrieves the computers a specific operator administers
+func (ops *OperatorService) GetComputers(ctx context.Context, name string) ([]model.OperatorComputer, error) {
+	endpoint := "/api/operator/" + url.PathEscape(name) + "/computers"
+
+	// Perform the request with retry logic and limiter tag
+	resp, err := ops.client.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
+		return ops.client.Resty.R().
+			SetContext(ctx).
+			SetHeader("Accept", "application/xml").
+			Get(ops.client.BaseURL + ":" + strconv.Itoa(ops.client.PortNumber) + endpoint)
+	}, "bigfix_operator_computers")
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch computers for operator %s: %w", name, err)
+	}
+
+	if resp.Body != nil {
+		defer resp.Body.Close()
+	}
+
+	// Read the response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Parse XML for operator computers response
+	var result model.OperatorComputerListResponse
+	if err := xml.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse XML response: %w", err)
+	}
+
+	// Convert XML computers to OperatorComputer models
+	computers := make([]model.OperatorComputer, 0, len(result.Computers))
+	for _, computerXML := range result.Computers {
+		computer, err := computerXML.ToComputer()
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert computer XML to model: %w", err)
+		}
+		computers = append(computers, model.OperatorComputer{
+			Resource:       computer.Resource,
+			ID:             computer.ID,
+			LastReportTime: computer.LastReportTime,
+		})
+	}
+
+	ops.client.logger.Debug("API response", "operator_computers", computers)
+
+	return computers, nil
+}
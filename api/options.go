@@ -0,0 +1,199 @@
+package api
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/cookiejar"
+	"time"
+
+	"resty.dev/v3"
+)
+
+// DefaultTimeout is the default timeout of a request to the BigFix server
+const DefaultTimeout = 120 * time.Second
+
+// Logger is the logging interface used by the client.
+// It is satisfied by both hclog.Logger and *slog.Logger.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// RetryPolicy defines how failed requests are retried
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts of a request, including the initial one
+	MaxAttempts int
+	// MinDelay is the base delay of the exponential backoff between two attempts
+	MinDelay time.Duration
+}
+
+// DefaultRetryPolicy is the retry policy used unless WithRetryPolicy is given
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	MinDelay:    100 * time.Millisecond,
+}
+
+// Option configures a Client created by New
+type Option func(*options)
+
+type options struct {
+	port        int
+	userName    string
+	password    string
+	httpClient  *http.Client
+	tlsConfig   *tls.Config
+	logger      Logger
+	retryPolicy RetryPolicy
+	timeout     time.Duration
+}
+
+// WithPort sets the port of the BigFix server REST API, DefaultPort by default
+func WithPort(port int) Option {
+	return func(o *options) {
+		o.port = port
+	}
+}
+
+// WithBasicAuth sets the credentials of the operator used to authenticate requests
+func WithBasicAuth(userName, password string) Option {
+	return func(o *options) {
+		o.userName = userName
+		o.password = password
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to send requests, e.g. to keep its timeout, redirect policy or
+// cookie jar. The client is copied and its transport cloned, so that WithTLSConfig and the connection pool and
+// proxy settings of the Client do not affect other users of httpClient. Its transport must be nil, to use a
+// clone of http.DefaultTransport, or an *http.Transport. A cookie jar is added if it has none, as required by
+// session authentication.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithTLSConfig sets the TLS settings used to connect to the BigFix server, see TLSOptions.
+// By default the server certificate is verified against the system roots.
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(o *options) {
+		o.tlsConfig = tlsConfig
+	}
+}
+
+// WithLogger sets the logger the client writes requests, retries and responses to.
+// By default nothing is logged.
+func WithLogger(logger Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithRetryPolicy sets how failed requests are retried, DefaultRetryPolicy by default
+func WithRetryPolicy(retryPolicy RetryPolicy) Option {
+	return func(o *options) {
+		o.retryPolicy = retryPolicy
+	}
+}
+
+// WithTimeout sets the timeout of a single request attempt, DefaultTimeout by default
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// New returns a Client for the BigFix server REST API at serverName, configured by the given options.
+// It fails if the HTTP client given by WithHTTPClient cannot be used, or if the retry policy allows no attempt.
+func New(serverName string, opts ...Option) (*Client, error) {
+	o := options{
+		port:        DefaultPort,
+		logger:      slog.New(slog.DiscardHandler),
+		retryPolicy: DefaultRetryPolicy,
+		timeout:     DefaultTimeout,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.retryPolicy.MaxAttempts < 1 {
+		return nil, fmt.Errorf("invalid retry policy: %d max attempts. Must be at least 1", o.retryPolicy.MaxAttempts)
+	}
+
+	var client *resty.Client
+	if o.httpClient != nil {
+		httpClient, err := copyHTTPClient(o.httpClient)
+		if err != nil {
+			return nil, err
+		}
+		client = resty.NewWithClient(httpClient)
+	} else {
+		client = resty.New()
+	}
+
+	// Configure timeouts - increased to handle large datasets
+	client.SetTimeout(o.timeout)
+
+	if o.tlsConfig != nil {
+		client.SetTLSClientConfig(o.tlsConfig)
+	}
+
+	bigfixClient := &Client{
+		Resty:      client,
+		BaseURL:    fmt.Sprintf(BaseURL, serverName),
+		PortNumber: o.port,
+		MaxRetries: o.retryPolicy.MaxAttempts,
+		MinDelay:   o.retryPolicy.MinDelay,
+		userName:   o.userName,
+		password:   o.password,
+		logger:     o.logger,
+	}
+
+	// Credentials are added per request as they depend on the authentication mode
+	client.AddRequestMiddleware(bigfixClient.authenticate)
+
+	// Initialize service clients
+	bigfixClient.Computer = NewComputerService(bigfixClient)
+	bigfixClient.Site = NewSiteService(bigfixClient)
+	bigfixClient.Analysis = NewAnalysisService(bigfixClient)
+	bigfixClient.Task = NewTaskService(bigfixClient)
+	bigfixClient.Action = NewActionService(bigfixClient)
+	bigfixClient.Fixlet = NewFixletService(bigfixClient)
+	bigfixClient.Property = NewPropertyService(bigfixClient)
+	bigfixClient.Role = NewRoleService(bigfixClient)
+	bigfixClient.Query = NewQueryService(bigfixClient)
+	bigfixClient.ComputerGroup = NewComputerGroupService(bigfixClient)
+	bigfixClient.Baseline = NewBaselineService(bigfixClient)
+	bigfixClient.Operator = NewOperatorService(bigfixClient)
+
+	return bigfixClient, nil
+}
+
+// copyHTTPClient returns a copy of httpClient with a cloned transport and a cookie jar,
+// which the Client can configure without affecting httpClient
+func copyHTTPClient(httpClient *http.Client) (*http.Client, error) {
+	c := *httpClient
+
+	switch transport := c.Transport.(type) {
+	case nil:
+		c.Transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		c.Transport = transport.Clone()
+	default:
+		return nil, fmt.Errorf("invalid HTTP client: transport must be an *http.Transport, got %T", c.Transport)
+	}
+
+	if c.Jar == nil {
+		jar, err := cookiejar.New(nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HTTP client: %w", err)
+		}
+		c.Jar = jar
+	}
+
+	return &c, nil
+}
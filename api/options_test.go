@@ -0,0 +1,111 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// roundTripperFunc is an http.RoundTripper which is not an *http.Transport
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestNewWithHTTPClient(t *testing.T) {
+	shared := &http.Transport{MaxIdleConnsPerHost: 2}
+
+	tests := []struct {
+		name       string
+		httpClient *http.Client
+		wantErr    bool
+	}{
+		{name: "nil transport", httpClient: &http.Client{Timeout: time.Minute}},
+		{name: "http transport", httpClient: &http.Client{Transport: shared}},
+		{name: "other round tripper", httpClient: &http.Client{Transport: roundTripperFunc(http.DefaultTransport.RoundTrip)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := New("bigfix.example.com", WithHTTPClient(tt.httpClient))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("no error for a transport which cannot be configured")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("new: %v", err)
+			}
+
+			httpClient := client.Resty.Client()
+			if httpClient == tt.httpClient {
+				t.Error("the given HTTP client is used without copying it")
+			}
+			if httpClient.Jar == nil {
+				t.Error("no cookie jar for session authentication")
+			}
+
+			// Connection pool and proxy settings apply to the client only
+			client.WithMaxIdleConnsPerHost(20).WithProxy("http://proxy:3128", "")
+			transport, err := client.Resty.HTTPTransport()
+			if err != nil {
+				t.Fatalf("transport: %v", err)
+			}
+			if transport == shared || transport == http.DefaultTransport {
+				t.Error("the given transport is modified")
+			}
+			if transport.MaxIdleConnsPerHost != 20 {
+				t.Errorf("got %d max idle connections per host, want 20", transport.MaxIdleConnsPerHost)
+			}
+			if transport.Proxy == nil {
+				t.Error("proxy not set")
+			}
+			if shared.MaxIdleConnsPerHost != 2 || shared.Proxy != nil {
+				t.Error("the shared transport was modified")
+			}
+		})
+	}
+}
+
+func TestNewRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxAttempts int
+		wantErr     bool
+	}{
+		{name: "single attempt", maxAttempts: 1, wantErr: false},
+		{name: "retries", maxAttempts: 5, wantErr: false},
+		{name: "no attempt", maxAttempts: 0, wantErr: true},
+		{name: "negative", maxAttempts: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New("bigfix.example.com", WithRetryPolicy(RetryPolicy{MaxAttempts: tt.maxAttempts}))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExecuteWithRetryNoAttempt(t *testing.T) {
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+
+	// MaxRetries changed after New still makes one attempt instead of returning a nil response
+	client.MaxRetries = 0
+	_, err := client.testGet(t.Context(), "/api/sites")
+
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) {
+		t.Fatalf("got error %v, want *RetryError", err)
+	}
+	if retryErr.Attempts != 1 || retryErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("got %d attempts and status %d, want 1 and 503", retryErr.Attempts, retryErr.StatusCode)
+	}
+}
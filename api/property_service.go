@@ -8,7 +8,6 @@ import (
 	"strconv"
 
 	"github.com/turbot/steampipe-plugin-bigfix/api/model"
-	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"resty.dev/v3"
 )
 
@@ -26,38 +25,42 @@ func NewPropertyService(client *Client) *PropertyService {
 
 // List retrieves all properties
 func (ps *PropertyService) List(ctx context.Context) ([]model.BigFixProperty, error) {
+	var properties []model.BigFixProperty
+	err := ps.ListFunc(ctx, func(property model.BigFixProperty) bool {
+		properties = append(properties, property)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ps.client.logger.Debug("API response", "properties", properties)
+
+	return properties, nil
+}
+
+// ListFunc streams all properties to fn as they are decoded from the response, stopping as soon as fn returns false
+func (ps *PropertyService) ListFunc(ctx context.Context, fn func(model.BigFixProperty) bool) error {
 	endpoint := "/api/properties"
 
 	// Perform the request with retry logic and limiter tag
-	resp, err := ps.client.executeWithRetryDefaultWithLimiter(func() (*resty.Response, error) {
+	resp, err := ps.client.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
 		return ps.client.Resty.R().
+			SetContext(ctx).
 			SetHeader("Accept", "application/xml").
 			Get(ps.client.BaseURL + ":" + strconv.Itoa(ps.client.PortNumber) + endpoint)
 	}, "bigfix_property_list")
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch properties: %w", err)
+		return fmt.Errorf("failed to fetch properties: %w", err)
 	}
 
 	if resp.Body != nil {
 		defer resp.Body.Close()
 	}
 
-	// Read the response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	// Parse XML for properties response
-	var result model.BigFixPropertyListResponse
-	if err := xml.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse XML response: %w", err)
-	}
-
-	plugin.Logger(ctx).Debug("API response properties:", result.Properties)
-
-	return result.Properties, nil
+	// Decode the properties one at a time
+	return decodeXMLList(resp.Body, "Property", fn)
 }
 
 // Get retrieves a specific property detail
@@ -65,8 +68,9 @@ func (ps *PropertyService) Get(ctx context.Context, propertyID int) (*model.BigF
 	endpoint := "/api/property/" + strconv.Itoa(propertyID)
 
 	// Perform the request with retry logic and limiter tag
-	resp, err := ps.client.executeWithRetryDefaultWithLimiter(func() (*resty.Response, error) {
+	resp, err := ps.client.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
 		return ps.client.Resty.R().
+			SetContext(ctx).
 			SetHeader("Accept", "application/xml").
 			Get(ps.client.BaseURL + ":" + strconv.Itoa(ps.client.PortNumber) + endpoint)
 	}, "bigfix_property_get")
@@ -95,7 +99,7 @@ func (ps *PropertyService) Get(ctx context.Context, propertyID int) (*model.BigF
 	resourceURL := ps.client.BaseURL + ":" + strconv.Itoa(ps.client.PortNumber) + endpoint
 	property := result.Property.ToBigFixProperty(propertyID, resourceURL, result.Property.Name, 0) // IsReserved not available in detail response
 
-	plugin.Logger(ctx).Debug("API response property:", property)
+	ps.client.logger.Debug("API response", "property", property)
 
 	return property, nil
 }
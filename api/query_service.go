@@ -0,0 +1,163 @@
+package api
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/turbot/steampipe-plugin-bigfix/api/model"
+	"resty.dev/v3"
+)
+
+// QueryService encapsulates the API logic for session relevance queries
+type QueryService struct {
+	client *Client
+}
+
+// NewQueryService creates a new QueryService
+func NewQueryService(client *Client) *QueryService {
+	return &QueryService{
+		client: client,
+	}
+}
+
+// relevanceStringEscaper percent-encodes the characters which cannot appear as is in a relevance string
+var relevanceStringEscaper = strings.NewReplacer(
+	"%", "%25",
+	`"`, "%22",
+	"\n", "%0a",
+	"\r", "%0d",
+)
+
+// RelevanceString returns s as a relevance string literal
+func RelevanceString(s string) string {
+	return `"` + relevanceStringEscaper.Replace(s) + `"`
+}
+
+// Run evaluates a session relevance expression on the server.
+//
+// Relevance evaluation errors are returned in the Error field of the result
+// rather than as a Go error, since the server still answers with HTTP 200.
+func (qs *QueryService) Run(ctx context.Context, relevance string) (*model.Query, error) {
+	resp, err := qs.request(ctx, relevance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run query: %w", err)
+	}
+
+	if resp.Body != nil {
+		defer resp.Body.Close()
+	}
+
+	// Read the response body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// Parse XML for query response
+	var result model.QueryResponse
+	if err := xml.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse XML response: %w", err)
+	}
+
+	query := result.Query.ToQuery(relevance)
+
+	qs.client.logger.Debug("API response", "query", query)
+
+	return query, nil
+}
+
+// RunFunc evaluates a session relevance expression on the server like Run, streaming each result to fn as it
+// is decoded instead of holding all of them in memory, and stopping as soon as fn returns false.
+// A relevance evaluation error is returned as an error, after the results preceding it were streamed.
+func (qs *QueryService) RunFunc(ctx context.Context, relevance string, fn func(model.QueryResult) bool) error {
+	resp, err := qs.request(ctx, relevance)
+	if err != nil {
+		return fmt.Errorf("failed to run query: %w", err)
+	}
+
+	if resp.Body != nil {
+		defer resp.Body.Close()
+	}
+
+	var queryError string
+	index := 0
+	err = decodeXMLElements(resp.Body, map[string]elementHandler{
+		"Query": func(decoder *xml.Decoder, start xml.StartElement) error {
+			var err error
+			queryError, err = decodeQueryResults(decoder, func(item model.QueryItemXML) bool {
+				result := item.ToResult(index)
+				index++
+				return fn(result)
+			})
+			return err
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if queryError = strings.TrimSpace(queryError); queryError != "" {
+		return fmt.Errorf("relevance evaluation error: %s", queryError)
+	}
+	return nil
+}
+
+// request sends a session relevance query with retry logic and limiter tag
+func (qs *QueryService) request(ctx context.Context, relevance string) (*resty.Response, error) {
+	params := url.Values{}
+	params.Add("relevance", relevance)
+	endpoint := "/api/query?" + params.Encode()
+
+	return qs.client.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
+		return qs.client.Resty.R().
+			SetContext(ctx).
+			SetHeader("Accept", "application/xml").
+			Get(qs.client.BaseURL + ":" + strconv.Itoa(qs.client.PortNumber) + endpoint)
+	}, "bigfix_query")
+}
+
+// decodeQueryResults decodes the content of a Query element, passing each Answer or Tuple of its
+// Result to fn until it returns false. It returns the text of the Error element, if any.
+func decodeQueryResults(decoder *xml.Decoder, fn func(model.QueryItemXML) bool) (string, error) {
+	var queryError string
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", fmt.Errorf("failed to parse XML response: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "Result":
+				// Descend into the answers and tuples of the result
+			case "Answer", "Tuple":
+				var item model.QueryItemXML
+				if err := decoder.DecodeElement(&item, &t); err != nil {
+					return "", fmt.Errorf("failed to parse XML response: %w", err)
+				}
+				if !fn(item) {
+					return "", errStopDecoding
+				}
+			case "Error":
+				if err := decoder.DecodeElement(&queryError, &t); err != nil {
+					return "", fmt.Errorf("failed to parse XML response: %w", err)
+				}
+			default:
+				if err := decoder.Skip(); err != nil {
+					return "", fmt.Errorf("failed to parse XML response: %w", err)
+				}
+			}
+		case xml.EndElement:
+			if t.Name.Local == "Query" {
+				return queryError, nil
+			}
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-bigfix/api/model"
+)
+
+// queryServer answers /api/query requests with body, recording the relevance of the last request
+func queryServer(body string, relevance *string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/query" {
+			http.NotFound(w, r)
+			return
+		}
+		*relevance = r.URL.Query().Get("relevance")
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write([]byte(body))
+	})
+}
+
+func TestQueryRunFunc(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		stop    int
+		want    []model.QueryResult
+		wantErr string
+	}{
+		{
+			name: "answers and tuples",
+			body: `<BESAPI><Query Resource="r"><Result>
+				<Answer type="string">a</Answer>
+				<Tuple><Answer type="string">b</Answer><Answer type="integer">1</Answer></Tuple>
+			</Result><Evaluation><Time>1ms</Time></Evaluation></Query></BESAPI>`,
+			want: []model.QueryResult{
+				{TupleIndex: 0, Answer: "a", Type: "string", Values: []model.QueryAnswer{{Type: "string", Value: "a"}}},
+				{
+					TupleIndex: 1,
+					Answer:     "b, 1",
+					Type:       "( string, integer )",
+					Values:     []model.QueryAnswer{{Type: "string", Value: "b"}, {Type: "integer", Value: "1"}},
+				},
+			},
+		},
+		{
+			name: "stopped by fn",
+			body: `<BESAPI><Query><Result>
+				<Answer type="string">a</Answer>
+				<Answer type="string">b</Answer>
+				<Answer type="string">c</Answer>
+			</Result></Query></BESAPI>`,
+			stop: 1,
+			want: []model.QueryResult{
+				{TupleIndex: 0, Answer: "a", Type: "string", Values: []model.QueryAnswer{{Type: "string", Value: "a"}}},
+			},
+		},
+		{
+			name: "evaluation error after results",
+			body: `<BESAPI><Query><Result>
+				<Answer type="string">a</Answer>
+			</Result><Error>
+				Singular expression refers to nonexistent object.
+			</Error></Query></BESAPI>`,
+			want: []model.QueryResult{
+				{TupleIndex: 0, Answer: "a", Type: "string", Values: []model.QueryAnswer{{Type: "string", Value: "a"}}},
+			},
+			wantErr: "relevance evaluation error: Singular expression refers to nonexistent object.",
+		},
+		{
+			name:    "invalid XML",
+			body:    `<BESAPI><Query><Result><Answer type="string">a</Answ`,
+			wantErr: "failed to parse XML response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var relevance string
+			client, _ := newTestClient(t, queryServer(tt.body, &relevance))
+
+			var got []model.QueryResult
+			err := client.Query.RunFunc(context.Background(), "names of bes sites", func(result model.QueryResult) bool {
+				got = append(got, result)
+				return tt.stop == 0 || len(got) < tt.stop
+			})
+
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("got error %v, want %q", err, tt.wantErr)
+			}
+			if relevance != "names of bes sites" {
+				t.Errorf("got relevance %q", relevance)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputerListWhereFunc(t *testing.T) {
+	body := `<BESAPI><Query><Result>
+		<Tuple>
+			<Answer type="string">12</Answer>
+			<Answer type="string">host-1</Answer>
+			<Answer type="string">Win2019 10.0.17763.1234</Answer>
+			<Answer type="string">Tue, 15 Oct 2024 10:20:30 +0000</Answer>
+			<Answer type="string">2400 MHz Xeon</Answer>
+			<Answer type="string">10.0.0.1</Answer>
+			<Answer type="string">a%0ab</Answer>
+			<Answer type="string"></Answer>
+		</Tuple>
+		<Tuple>
+			<Answer type="string">13</Answer>
+			<Answer type="string">host-2</Answer>
+			<Answer type="string"></Answer>
+			<Answer type="string"></Answer>
+			<Answer type="string"></Answer>
+			<Answer type="string"></Answer>
+			<Answer type="string"></Answer>
+			<Answer type="string">x</Answer>
+		</Tuple>
+	</Result></Query></BESAPI>`
+	body = strings.ReplaceAll(body, "%0a", "\n")
+
+	var relevance string
+	client, _ := newTestClient(t, queryServer(body, &relevance))
+
+	var computers []model.Computer
+	err := client.Computer.ListWhereFunc(context.Background(), `name of it starts with "host"`, []string{"P1", "P2"}, func(computer model.Computer) bool {
+		computers = append(computers, computer)
+		return true
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasSuffix(relevance, ` of bes computers whose (name of it starts with "host")`) {
+		t.Errorf("got relevance %q", relevance)
+	}
+	if !strings.Contains(relevance, `values of results (bes property "P2", it)`) {
+		t.Errorf("relevance %q misses property P2", relevance)
+	}
+
+	if len(computers) != 2 {
+		t.Fatalf("got %d computers, want 2", len(computers))
+	}
+	if computers[0].ID != 12 || computers[0].Name != "host-1" || computers[0].IPAddress != "10.0.0.1" {
+		t.Errorf("got computer %+v", computers[0])
+	}
+	if computers[1].ID != 13 || computers[1].Name != "host-2" {
+		t.Errorf("got computer %+v", computers[1])
+	}
+
+	wantProperties := [][]model.Property{
+		{{Name: "P1", Value: "a"}, {Name: "P1", Value: "b"}},
+		{{Name: "P2", Value: "x"}},
+	}
+	for i, want := range wantProperties {
+		if !reflect.DeepEqual(computers[i].Properties, want) {
+			t.Errorf("got properties %+v for computer %d, want %+v", computers[i].Properties, i, want)
+		}
+	}
+}
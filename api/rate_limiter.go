@@ -0,0 +1,151 @@
+package api
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimitConfig holds the request rate and concurrency limits applied to API calls.
+// A zero value for any limit means unlimited.
+type RateLimitConfig struct {
+	// RequestsPerSecond limits the rate of all requests sent to the BigFix server
+	RequestsPerSecond float64
+	// MaxConcurrency limits the number of requests in flight to the BigFix server
+	MaxConcurrency int
+	// TagRequestsPerSecond limits the rate of requests per limiter tag, keyed by tag or tag prefix
+	TagRequestsPerSecond map[string]float64
+	// TagMaxConcurrency limits the number of requests in flight per limiter tag, keyed by tag or tag prefix
+	TagMaxConcurrency map[string]int
+}
+
+// IsZero reports whether the config does not limit any requests
+func (rc RateLimitConfig) IsZero() bool {
+	return rc.RequestsPerSecond <= 0 && rc.MaxConcurrency <= 0 && len(rc.TagRequestsPerSecond) == 0 && len(rc.TagMaxConcurrency) == 0
+}
+
+// RateLimiter throttles API requests globally and per limiter tag.
+// It is safe for concurrent use and is intended to be shared by all clients of a connection.
+type RateLimiter struct {
+	global *limit
+	tags   map[string]*limit
+}
+
+// limit combines a token bucket with a concurrency semaphore, either of which may be nil
+type limit struct {
+	bucket *rate.Limiter
+	slots  chan struct{}
+}
+
+// NewRateLimiter creates a RateLimiter from the given config
+func NewRateLimiter(config RateLimitConfig) *RateLimiter {
+	rl := &RateLimiter{
+		global: newLimit(config.RequestsPerSecond, config.MaxConcurrency),
+		tags:   map[string]*limit{},
+	}
+
+	for tag, rps := range config.TagRequestsPerSecond {
+		rl.tags[tag] = newLimit(rps, config.TagMaxConcurrency[tag])
+	}
+	for tag, concurrency := range config.TagMaxConcurrency {
+		if _, ok := rl.tags[tag]; !ok {
+			rl.tags[tag] = newLimit(0, concurrency)
+		}
+	}
+
+	return rl
+}
+
+// Acquire blocks until a request with the given limiter tag may be sent, or the context is done.
+// The returned function must be called once the request has completed to free its concurrency slots.
+func (rl *RateLimiter) Acquire(ctx context.Context, limiterTag string) (func(), error) {
+	return rl.acquire(ctx, limiterTag, true)
+}
+
+// acquireSlots blocks until a concurrency slot of the given limiter tag is free, or the context is done,
+// without counting a new request against the rate limits. It is used to read the body of a response.
+func (rl *RateLimiter) acquireSlots(ctx context.Context, limiterTag string) (func(), error) {
+	return rl.acquire(ctx, limiterTag, false)
+}
+
+// acquire takes the concurrency slots of the limiter tag, and waits for the rate limits if rated is set
+func (rl *RateLimiter) acquire(ctx context.Context, limiterTag string, rated bool) (func(), error) {
+	var releases []func()
+	release := func() {
+		for i := len(releases) - 1; i >= 0; i-- {
+			releases[i]()
+		}
+	}
+
+	// Acquire the most specific limit first so a request waiting on its tag does not hold a global slot
+	for _, l := range []*limit{rl.tagLimit(limiterTag), rl.global} {
+		if l == nil {
+			continue
+		}
+		r, err := l.acquire(ctx, rated)
+		if err != nil {
+			release()
+			return nil, err
+		}
+		releases = append(releases, r)
+	}
+
+	return release, nil
+}
+
+// tagLimit returns the limit configured for the longest key matching the tag, either exactly or as a prefix
+func (rl *RateLimiter) tagLimit(limiterTag string) *limit {
+	var match string
+	for tag := range rl.tags {
+		if strings.HasPrefix(limiterTag, tag) && len(tag) > len(match) {
+			match = tag
+		}
+	}
+	if match == "" {
+		return nil
+	}
+	return rl.tags[match]
+}
+
+func newLimit(rps float64, concurrency int) *limit {
+	if rps <= 0 && concurrency <= 0 {
+		return nil
+	}
+
+	l := &limit{}
+	if rps > 0 {
+		// Allow a burst of up to one second worth of requests
+		l.bucket = rate.NewLimiter(rate.Limit(rps), int(math.Max(1, math.Ceil(rps))))
+	}
+	if concurrency > 0 {
+		l.slots = make(chan struct{}, concurrency)
+	}
+	return l
+}
+
+func (l *limit) acquire(ctx context.Context, rated bool) (func(), error) {
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	release := func() {
+		if l.slots != nil {
+			<-l.slots
+		}
+	}
+
+	if rated && l.bucket != nil {
+		if err := l.bucket.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	return release, nil
+}
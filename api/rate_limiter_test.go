@@ -0,0 +1,203 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterMaxConcurrency(t *testing.T) {
+	tests := []struct {
+		name   string
+		config RateLimitConfig
+		tag    string
+		want   int32
+	}{
+		{
+			name:   "global",
+			config: RateLimitConfig{MaxConcurrency: 2},
+			tag:    "bigfix_computer",
+			want:   2,
+		},
+		{
+			name:   "tag prefix",
+			config: RateLimitConfig{MaxConcurrency: 4, TagMaxConcurrency: map[string]int{"bigfix_": 3, "bigfix_computer": 1}},
+			tag:    "bigfix_computer_get",
+			want:   1,
+		},
+		{
+			name:   "other tag",
+			config: RateLimitConfig{MaxConcurrency: 4, TagMaxConcurrency: map[string]int{"bigfix_computer": 1}},
+			tag:    "bigfix_site",
+			want:   4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rl := NewRateLimiter(tt.config)
+
+			var inFlight, maxInFlight atomic.Int32
+			var wg sync.WaitGroup
+			for range 10 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+
+					release, err := rl.Acquire(context.Background(), tt.tag)
+					if err != nil {
+						t.Errorf("acquire: %v", err)
+						return
+					}
+					defer release()
+
+					n := inFlight.Add(1)
+					for {
+						m := maxInFlight.Load()
+						if n <= m || maxInFlight.CompareAndSwap(m, n) {
+							break
+						}
+					}
+					time.Sleep(10 * time.Millisecond)
+					inFlight.Add(-1)
+				}()
+			}
+			wg.Wait()
+
+			if got := maxInFlight.Load(); got != tt.want {
+				t.Errorf("got %d requests in flight, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimiterRequestsPerSecond(t *testing.T) {
+	rl := NewRateLimiter(RateLimitConfig{TagRequestsPerSecond: map[string]float64{"bigfix_query": 20}})
+
+	// The first second worth of requests is allowed as a burst, the next ones wait for the bucket
+	start := time.Now()
+	for range 30 {
+		release, err := rl.Acquire(context.Background(), "bigfix_query")
+		if err != nil {
+			t.Fatalf("acquire: %v", err)
+		}
+		release()
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("30 requests at 20 per second took %s", elapsed)
+	}
+
+	// Other tags are not limited
+	start = time.Now()
+	for range 30 {
+		release, err := rl.Acquire(context.Background(), "bigfix_site")
+		if err != nil {
+			t.Fatalf("acquire: %v", err)
+		}
+		release()
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("30 unlimited requests took %s", elapsed)
+	}
+}
+
+func TestRateLimiterAcquireCancelled(t *testing.T) {
+	rl := NewRateLimiter(RateLimitConfig{MaxConcurrency: 1})
+
+	release, err := rl.Acquire(context.Background(), "bigfix_site")
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := rl.Acquire(ctx, "bigfix_site"); err == nil {
+		t.Error("acquire succeeded while the only slot is held")
+	}
+}
+
+func TestRateLimitConfigIsZero(t *testing.T) {
+	if !(RateLimitConfig{}).IsZero() {
+		t.Error("empty config is not zero")
+	}
+	if (RateLimitConfig{TagMaxConcurrency: map[string]int{"bigfix_query": 1}}).IsZero() {
+		t.Error("config with a tag limit is zero")
+	}
+}
+
+func TestRateLimiterHoldsSlotWhileBodyIsStreamed(t *testing.T) {
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// XML bodies are left to the caller to stream instead of being read by resty
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write([]byte("<BESAPI></BESAPI>"))
+	}))
+	client = client.WithRateLimiter(NewRateLimiter(RateLimitConfig{MaxConcurrency: 1}))
+
+	first, err := client.testGet(context.Background(), "/api/sites")
+	if err != nil {
+		t.Fatalf("first request: %v", err)
+	}
+
+	// The body of the first response is still open, the second request must wait for its slot
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.testGet(ctx, "/api/sites"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got error %v while the first body is open, want the request to block", err)
+	}
+
+	if _, err := io.ReadAll(first.Body); err != nil {
+		t.Fatalf("read first body: %v", err)
+	}
+	first.Body.Close()
+
+	second, err := client.testGet(context.Background(), "/api/sites")
+	if err != nil {
+		t.Fatalf("got error %v once the first body was closed", err)
+	}
+	second.Body.Close()
+}
+
+func TestRateLimiterFreesSlotBetweenBodyReads(t *testing.T) {
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write([]byte("<BESAPI></BESAPI>"))
+	}))
+	client = client.WithRateLimiter(NewRateLimiter(RateLimitConfig{MaxConcurrency: 1}))
+
+	first, err := client.testGet(context.Background(), "/api/sites")
+	if err != nil {
+		t.Fatalf("first request: %v", err)
+	}
+	defer first.Body.Close()
+
+	// A caller processing the items decoded so far, e.g. streaming rows whose hydrate functions
+	// send requests of their own, does not hold the slot of the body it is reading
+	if _, err := first.Body.Read(make([]byte, 4)); err != nil {
+		t.Fatalf("read first body: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	second, err := client.testGet(ctx, "/api/sites")
+	if err != nil {
+		t.Fatalf("got error %v while the first body is being processed", err)
+	}
+	if _, err := io.ReadAll(second.Body); err != nil {
+		t.Fatalf("read second body: %v", err)
+	}
+	second.Body.Close()
+
+	rest, err := io.ReadAll(first.Body)
+	if err != nil {
+		t.Fatalf("read first body: %v", err)
+	}
+	if string(rest) != "API></BESAPI>" {
+		t.Errorf("got the rest of the first body %q", rest)
+	}
+}
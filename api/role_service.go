@@ -8,7 +8,6 @@ import (
 	"strconv"
 
 	"github.com/turbot/steampipe-plugin-bigfix/api/model"
-	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"resty.dev/v3"
 )
 
@@ -26,38 +25,42 @@ func NewRoleService(client *Client) *RoleService {
 
 // List retrieves all roles
 func (rs *RoleService) List(ctx context.Context) ([]model.Role, error) {
+	var roles []model.Role
+	err := rs.ListFunc(ctx, func(role model.Role) bool {
+		roles = append(roles, role)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rs.client.logger.Debug("API response", "roles", roles)
+
+	return roles, nil
+}
+
+// ListFunc streams all roles to fn as they are decoded from the response, stopping as soon as fn returns false
+func (rs *RoleService) ListFunc(ctx context.Context, fn func(model.Role) bool) error {
 	endpoint := "/api/roles"
 
 	// Perform the request with retry logic and limiter tag
-	resp, err := rs.client.executeWithRetryDefaultWithLimiter(func() (*resty.Response, error) {
+	resp, err := rs.client.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
 		return rs.client.Resty.R().
+			SetContext(ctx).
 			SetHeader("Accept", "application/xml").
 			Get(rs.client.BaseURL + ":" + strconv.Itoa(rs.client.PortNumber) + endpoint)
 	}, "bigfix_role_list")
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch roles: %w", err)
+		return fmt.Errorf("failed to fetch roles: %w", err)
 	}
 
 	if resp.Body != nil {
 		defer resp.Body.Close()
 	}
 
-	// Read the response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	// Parse XML for roles response
-	var result model.RoleListResponse
-	if err := xml.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse XML response: %w", err)
-	}
-
-	plugin.Logger(ctx).Debug("API response roles:", result.Roles)
-
-	return result.Roles, nil
+	// Decode the roles one at a time
+	return decodeXMLList(resp.Body, "Role", fn)
 }
 
 // Get retrieves a specific role detail
@@ -65,8 +68,9 @@ func (rs *RoleService) Get(ctx context.Context, roleID int) (*model.Role, error)
 	endpoint := "/api/role/" + strconv.Itoa(roleID)
 
 	// Perform the request with retry logic and limiter tag
-	resp, err := rs.client.executeWithRetryDefaultWithLimiter(func() (*resty.Response, error) {
+	resp, err := rs.client.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
 		return rs.client.Resty.R().
+			SetContext(ctx).
 			SetHeader("Accept", "application/xml").
 			Get(rs.client.BaseURL + ":" + strconv.Itoa(rs.client.PortNumber) + endpoint)
 	}, "bigfix_role_get")
@@ -94,7 +98,9 @@ func (rs *RoleService) Get(ctx context.Context, roleID int) (*model.Role, error)
 	// Set the resource URL for the role
 	result.Role.Resource = rs.client.BaseURL + ":" + strconv.Itoa(rs.client.PortNumber) + endpoint
 
-	plugin.Logger(ctx).Debug("API response role:", result.Role)
+	role := result.Role.ToRole()
+
+	rs.client.logger.Debug("API response", "role", role)
 
-	return &result.Role, nil
+	return role, nil
 }
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"resty.dev/v3"
+)
+
+// Authentication modes supported by the client
+const (
+	// AuthModeBasic sends the credentials with every request
+	AuthModeBasic = "basic"
+	// AuthModeSession logs in once through /api/login and reuses the session cookie returned by the server
+	AuthModeSession = "session"
+)
+
+// session tracks the login state of a client using session authentication.
+// The session cookie itself is kept by the cookie jar of the Resty client.
+type session struct {
+	mu sync.Mutex
+	// generation is incremented on every successful login, so that concurrent requests
+	// failing with the same expired session only trigger a single login
+	generation int
+	loggedIn   bool
+}
+
+// WithSessionAuth authenticates through /api/login on the first request and reuses the session cookie
+// returned by the server for later requests, instead of sending the credentials with every request.
+// This avoids an LDAP bind on the BigFix server for each call. Expired sessions are renewed transparently.
+func (c *Client) WithSessionAuth() *Client {
+	c.session = &session{}
+	return c
+}
+
+// authenticate is a Resty request middleware adding the basic auth credentials to requests,
+// unless the client uses session authentication
+func (c *Client) authenticate(_ *resty.Client, r *resty.Request) error {
+	if c.session == nil {
+		r.SetBasicAuth(c.userName, c.password)
+	}
+	return nil
+}
+
+// withSession wraps a request so that it is sent within a valid session, logging in first if needed
+// and logging in again then replaying the request once if the server rejects the session
+func (c *Client) withSession(ctx context.Context, request func() (*resty.Response, error)) func() (*resty.Response, error) {
+	return func() (*resty.Response, error) {
+		generation, err := c.ensureSession(ctx, -1)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := request()
+		if err != nil || resp.StatusCode() != http.StatusUnauthorized {
+			return resp, err
+		}
+
+		c.logger.Info("Session rejected by the server (401), logging in again")
+		discardBody(resp)
+		if _, err := c.ensureSession(ctx, generation); err != nil {
+			return nil, err
+		}
+		return request()
+	}
+}
+
+// ensureSession logs in unless the client already holds a session. When expired is a session generation,
+// that session is known to be rejected and a new login is made unless another request already renewed it.
+// It returns the generation of the current session.
+func (c *Client) ensureSession(ctx context.Context, expired int) (int, error) {
+	c.session.mu.Lock()
+	defer c.session.mu.Unlock()
+
+	if c.session.loggedIn && c.session.generation != expired {
+		return c.session.generation, nil
+	}
+
+	c.session.loggedIn = false
+	if err := c.login(ctx); err != nil {
+		return 0, err
+	}
+	c.session.loggedIn = true
+	c.session.generation++
+
+	return c.session.generation, nil
+}
+
+// login authenticates against /api/login, the session cookie of the response is stored in the cookie jar
+func (c *Client) login(ctx context.Context) error {
+	endpoint := "/api/login"
+
+	resp, err := c.Resty.R().
+		SetContext(ctx).
+		SetBasicAuth(c.userName, c.password).
+		Get(c.BaseURL + ":" + strconv.Itoa(c.PortNumber) + endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to log in: %w", err)
+	}
+
+	if !resp.IsSuccess() {
+		return fmt.Errorf("failed to log in: %w", newError(resp))
+	}
+
+	if len(resp.Cookies()) == 0 {
+		return fmt.Errorf("failed to log in: %s did not return a session cookie", endpoint)
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"resty.dev/v3"
+)
+
+// newTestClient returns a Client sending its requests to a TLS test server serving handler
+func newTestClient(t *testing.T, handler http.Handler, opts ...Option) (*Client, *httptest.Server) {
+	t.Helper()
+
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(serverURL.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts = append([]Option{
+		WithPort(port),
+		WithBasicAuth("user", "password"),
+		WithTLSConfig(&tls.Config{InsecureSkipVerify: true}),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, MinDelay: time.Millisecond}),
+	}, opts...)
+
+	client, err := New(serverURL.Hostname(), opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, server
+}
+
+// testGet sends a GET request for endpoint through the retry, rate limit and session logic of the client
+func (c *Client) testGet(ctx context.Context, endpoint string) (*resty.Response, error) {
+	return c.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
+		return c.Resty.R().
+			SetContext(ctx).
+			Get(c.BaseURL + ":" + strconv.Itoa(c.PortNumber) + endpoint)
+	}, "test")
+}
+
+// sessionServer accepts requests carrying the cookie of the current session only
+type sessionServer struct {
+	logins  atomic.Int32
+	current atomic.Int32
+}
+
+func (s *sessionServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/api/login" {
+		if user, password, ok := r.BasicAuth(); !ok || user != "user" || password != "password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		n := s.logins.Add(1)
+		s.current.Store(n)
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: strconv.Itoa(int(n)), Path: "/"})
+		return
+	}
+
+	if _, _, ok := r.BasicAuth(); ok {
+		http.Error(w, "basic auth sent with a session", http.StatusBadRequest)
+		return
+	}
+	cookie, err := r.Cookie("session")
+	if err != nil || cookie.Value != strconv.Itoa(int(s.current.Load())) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	w.Write([]byte("ok"))
+}
+
+// expire invalidates the current session
+func (s *sessionServer) expire() {
+	s.current.Store(-1)
+}
+
+func TestSessionLogin(t *testing.T) {
+	server := &sessionServer{}
+	client, _ := newTestClient(t, server)
+	client.WithSessionAuth()
+
+	for range 3 {
+		resp, err := client.testGet(context.Background(), "/api/test")
+		if err != nil {
+			t.Fatalf("request: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	if got := server.logins.Load(); got != 1 {
+		t.Errorf("got %d logins, want 1", got)
+	}
+}
+
+func TestSessionRelogin(t *testing.T) {
+	server := &sessionServer{}
+	client, _ := newTestClient(t, server)
+	client.WithSessionAuth()
+
+	resp, err := client.testGet(context.Background(), "/api/test")
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	resp.Body.Close()
+
+	// Requests failing concurrently with the same expired session trigger a single login
+	server.expire()
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.testGet(context.Background(), "/api/test")
+			if err != nil {
+				t.Errorf("request after expiry: %v", err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if got := server.logins.Load(); got != 2 {
+		t.Errorf("got %d logins, want 2", got)
+	}
+}
+
+func TestSessionLoginFailure(t *testing.T) {
+	server := &sessionServer{}
+	client, _ := newTestClient(t, server, WithBasicAuth("user", "wrong"))
+	client.WithSessionAuth()
+
+	if _, err := client.testGet(context.Background(), "/api/test"); err == nil {
+		t.Fatal("request succeeded with wrong credentials")
+	}
+	if got := server.logins.Load(); got != 0 {
+		t.Errorf("got %d logins, want 0", got)
+	}
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Relevance flags selecting the content types of a site
+const (
+	fixletFlag   = "fixlet flag of it"
+	taskFlag     = "task flag of it"
+	analysisFlag = "analysis flag of it"
+)
+
+// siteRelevance returns the relevance condition matching the site with the given name and type.
+// Custom and operator sites are also matched by their internal names, prefixed with CustomSite_ and mo_.
+func siteRelevance(siteName, siteType string) (string, error) {
+	name := RelevanceString(siteName)
+
+	switch siteType {
+	case "external", "action":
+		return "name of it = " + name, nil
+	case "custom":
+		return fmt.Sprintf("custom site flag of it and (name of it = %s or name of it = %s)", name, RelevanceString("CustomSite_"+siteName)), nil
+	case "operator":
+		return fmt.Sprintf("operator site flag of it and (name of it = %s or name of it = %s)", name, RelevanceString("mo_"+siteName)), nil
+	case "master":
+		return "master site flag of it", nil
+	default:
+		return "", fmt.Errorf("invalid site type: %s. Must be one of: external, operator, master, action, custom", siteType)
+	}
+}
+
+// querySiteContent evaluates the given tuple items for the fixlets of a site matching flag in a single
+// session relevance query. Each returned row holds the id of the content followed by the items.
+// Items which cannot be evaluated for a fixlet are returned as empty strings.
+func (c *Client) querySiteContent(ctx context.Context, siteName, siteType, flag string, items []string) (map[int][]string, error) {
+	site, err := siteRelevance(siteName, siteType)
+	if err != nil {
+		return nil, err
+	}
+
+	tuple := []string{"id of it as string"}
+	for _, item := range items {
+		tuple = append(tuple, fmt.Sprintf(`(%s | "")`, item))
+	}
+	relevance := fmt.Sprintf("(%s) of fixlets whose (%s) of bes sites whose (%s)", strings.Join(tuple, ", "), flag, site)
+
+	query, err := c.Query.Run(ctx, relevance)
+	if err != nil {
+		return nil, err
+	}
+	if query.Error != "" {
+		return nil, fmt.Errorf("relevance evaluation error: %s", query.Error)
+	}
+
+	rows := make(map[int][]string, len(query.Results))
+	for _, result := range query.Results {
+		if len(result.Values) != len(tuple) {
+			return nil, fmt.Errorf("unexpected query result with %d values", len(result.Values))
+		}
+
+		id, err := strconv.Atoi(result.Values[0].Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid id %q", result.Values[0].Value)
+		}
+
+		row := make([]string, len(items))
+		for i := range items {
+			row[i] = result.Values[i+1].Value
+		}
+		rows[id] = row
+	}
+
+	return rows, nil
+}
+
+// relevanceDate converts a date returned by relevance, e.g. "Tue, 15 Oct 2024", to the format used
+// in the XML detail of fixlets, e.g. "2024-10-15". Other values are returned unchanged.
+func relevanceDate(s string) string {
+	if t, err := time.Parse("Mon, 02 Jan 2006", s); err == nil {
+		return t.Format("2006-01-02")
+	}
+	return s
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-bigfix/api/model"
+)
+
+func TestFixletListDetails(t *testing.T) {
+	body := `<BESAPI><Query><Result>
+		<Tuple>
+			<Answer type="string">12</Answer>
+			<Answer type="string">Update "A"</Answer>
+			<Answer type="string">Security Update</Answer>
+			<Answer type="string">Critical</Answer>
+			<Answer type="string">CVE-2024-1, CVE-2024-2</Answer>
+			<Answer type="string">Tue, 15 Oct 2024</Answer>
+		</Tuple>
+		<Tuple>
+			<Answer type="string">13</Answer>
+			<Answer type="string">Update B</Answer>
+			<Answer type="string"></Answer>
+			<Answer type="string"></Answer>
+			<Answer type="string"></Answer>
+			<Answer type="string"></Answer>
+		</Tuple>
+	</Result></Query></BESAPI>`
+
+	var relevance string
+	client, _ := newTestClient(t, queryServer(body, &relevance))
+
+	fixlets, err := client.Fixlet.ListDetails(context.Background(), "BES Support", "external")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasSuffix(relevance, ` of fixlets whose (fixlet flag of it) of bes sites whose (name of it = "BES Support")`) {
+		t.Errorf("got relevance %q", relevance)
+	}
+	if strings.Contains(relevance, "relevance of it") {
+		t.Errorf("relevance %q fetches the combined relevance clauses", relevance)
+	}
+
+	want := map[int]model.Fixlet{
+		12: {
+			ID:                12,
+			Name:              `Update "A"`,
+			SiteName:          "BES Support",
+			SiteType:          "external",
+			Title:             `Update "A"`,
+			Category:          "Security Update",
+			SourceSeverity:    "Critical",
+			CVENames:          "CVE-2024-1, CVE-2024-2",
+			SourceReleaseDate: "2024-10-15",
+			DetailsFetched:    true,
+		},
+		13: {
+			ID:             13,
+			Name:           "Update B",
+			SiteName:       "BES Support",
+			SiteType:       "external",
+			Title:          "Update B",
+			DetailsFetched: true,
+		},
+	}
+	if !reflect.DeepEqual(fixlets, want) {
+		t.Errorf("got %+v, want %+v", fixlets, want)
+	}
+}
+
+func TestFixletListDetailsError(t *testing.T) {
+	body := `<BESAPI><Query><Result></Result><Error>The operator "fixlet flag" is not defined.</Error></Query></BESAPI>`
+
+	var relevance string
+	client, _ := newTestClient(t, queryServer(body, &relevance))
+
+	_, err := client.Fixlet.ListDetails(context.Background(), "BES Support", "external")
+	if err == nil || !strings.Contains(err.Error(), "relevance evaluation error") {
+		t.Fatalf("got error %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/turbot/steampipe-plugin-bigfix/api/model"
+)
+
+// DefaultSiteConcurrency is the number of sites processed at a time by ForEachSite when no concurrency is given
+const DefaultSiteConcurrency = 5
+
+// SiteError is the error of processing a single site in ForEachSite
+type SiteError struct {
+	SiteName string
+	SiteType string
+	Err      error
+}
+
+func (e *SiteError) Error() string {
+	return fmt.Sprintf("site %s (%s): %v", e.SiteName, e.SiteType, e.Err)
+}
+
+func (e *SiteError) Unwrap() error {
+	return e.Err
+}
+
+// SiteErrors is returned by ForEachSite when some of the sites failed, the others were processed successfully
+type SiteErrors struct {
+	// Errors holds the error of each failed site
+	Errors []*SiteError
+	// Total is the number of sites processed
+	Total int
+}
+
+func (e *SiteErrors) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("failed for %d of %d sites: %s", len(e.Errors), e.Total, strings.Join(messages, "; "))
+}
+
+func (e *SiteErrors) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// ForEachSite calls fn for each site, processing up to concurrency sites at a time.
+// A failed site does not stop the others: once all sites are processed, the failures are returned as *SiteErrors.
+// Sites not started yet are skipped when ctx is cancelled.
+func ForEachSite(ctx context.Context, sites []model.Site, concurrency int, fn func(context.Context, model.Site) error) error {
+	if concurrency <= 0 {
+		concurrency = DefaultSiteConcurrency
+	}
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		slots = make(chan struct{}, concurrency)
+		errs  []*SiteError
+	)
+
+	for _, site := range sites {
+		// Wait for a free slot, unless the query is cancelled
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(site model.Site) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			if err := fn(ctx, site); err != nil {
+				mu.Lock()
+				errs = append(errs, &SiteError{SiteName: site.Name, SiteType: site.Type, Err: err})
+				mu.Unlock()
+			}
+		}(site)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return &SiteErrors{Errors: errs, Total: len(sites)}
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-bigfix/api/model"
+)
+
+// testSites returns n sites named site-0 to site-<n-1>
+func testSites(n int) []model.Site {
+	sites := make([]model.Site, n)
+	for i := range sites {
+		sites[i] = model.Site{Name: "site-" + strconv.Itoa(i), Type: "external"}
+	}
+	return sites
+}
+
+func TestForEachSite(t *testing.T) {
+	sites := testSites(10)
+
+	var (
+		mu        sync.Mutex
+		processed = map[string]bool{}
+	)
+	err := ForEachSite(context.Background(), sites, 3, func(ctx context.Context, site model.Site) error {
+		mu.Lock()
+		processed[site.Name] = true
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(processed) != len(sites) {
+		t.Errorf("processed %d sites, want %d", len(processed), len(sites))
+	}
+}
+
+func TestForEachSiteConcurrency(t *testing.T) {
+	tests := []struct {
+		name        string
+		concurrency int
+		want        int32
+	}{
+		{name: "limited", concurrency: 2, want: 2},
+		{name: "default", concurrency: 0, want: DefaultSiteConcurrency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var running, peak atomic.Int32
+			err := ForEachSite(context.Background(), testSites(20), tt.concurrency, func(ctx context.Context, site model.Site) error {
+				n := running.Add(1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				running.Add(-1)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := peak.Load(); got > tt.want {
+				t.Errorf("got %d sites processed at a time, want at most %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestForEachSiteErrors(t *testing.T) {
+	sites := testSites(5)
+	failure := errors.New("boom")
+
+	var processed atomic.Int32
+	err := ForEachSite(context.Background(), sites, 2, func(ctx context.Context, site model.Site) error {
+		processed.Add(1)
+		if site.Name == "site-1" || site.Name == "site-3" {
+			return failure
+		}
+		return nil
+	})
+
+	// A failed site does not stop the others
+	if got := processed.Load(); got != int32(len(sites)) {
+		t.Errorf("processed %d sites, want %d", got, len(sites))
+	}
+
+	var siteErrors *SiteErrors
+	if !errors.As(err, &siteErrors) {
+		t.Fatalf("got error %v, want *SiteErrors", err)
+	}
+	if siteErrors.Total != len(sites) || len(siteErrors.Errors) != 2 {
+		t.Errorf("got %d errors of %d sites, want 2 of %d", len(siteErrors.Errors), siteErrors.Total, len(sites))
+	}
+
+	failed := map[string]bool{}
+	for _, siteErr := range siteErrors.Errors {
+		failed[siteErr.SiteName] = true
+		if siteErr.SiteType != "external" {
+			t.Errorf("got site type %q", siteErr.SiteType)
+		}
+	}
+	if !failed["site-1"] || !failed["site-3"] {
+		t.Errorf("got failed sites %v, want site-1 and site-3", failed)
+	}
+	if !errors.Is(err, failure) {
+		t.Error("the site errors do not unwrap to the failure")
+	}
+}
+
+func TestForEachSiteCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var processed atomic.Int32
+	err := ForEachSite(ctx, testSites(10), 1, func(ctx context.Context, site model.Site) error {
+		if processed.Add(1) == 2 {
+			cancel()
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The sites not started when the context was cancelled are skipped
+	if got := processed.Load(); got > 3 {
+		t.Errorf("processed %d sites after cancellation", got)
+	}
+}
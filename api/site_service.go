@@ -5,11 +5,11 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
+	"net/http"
 	"net/url"
 	"strconv"
 
 	"github.com/turbot/steampipe-plugin-bigfix/api/model"
-	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"resty.dev/v3"
 )
 
@@ -24,58 +24,50 @@ func NewSiteService(client *Client) *SiteService {
 }
 
 // List retrieves a list of sites from the BigFix API
-func (ss *SiteService) List() ([]model.Site, error) {
+func (ss *SiteService) List(ctx context.Context) ([]model.Site, error) {
+	var sites []model.Site
+	err := ss.ListFunc(ctx, func(site model.Site) bool {
+		sites = append(sites, site)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sites, nil
+}
+
+// ListFunc streams the sites to fn as they are decoded from the response, stopping as soon as fn returns false
+func (ss *SiteService) ListFunc(ctx context.Context, fn func(model.Site) bool) error {
 	endpoint := "/api/sites"
 
 	// Perform the request with retry logic and limiter tag
-	resp, err := ss.client.executeWithRetryDefaultWithLimiter(func() (*resty.Response, error) {
+	resp, err := ss.client.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
 		return ss.client.Resty.R().
+			SetContext(ctx).
 			SetHeader("Accept", "application/xml").
 			Get(ss.client.BaseURL + ":" + strconv.Itoa(ss.client.PortNumber) + endpoint)
 	}, "bigfix_site_list")
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch sites: %w", err)
-	}
-
-	// Read the response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	// Parse the XML response
-	var result model.SiteListResponse
-	if err := xml.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse XML response: %w", err)
-	}
-
-	// Convert XML sites to Site models
-	var sites []model.Site
-
-	// Add external sites
-	for _, externalSite := range result.ExternalSites {
-		site := externalSite.ToSite()
-		sites = append(sites, *site)
+		return fmt.Errorf("failed to fetch sites: %w", err)
 	}
 
-	// Add operator sites
-	for _, operatorSite := range result.OperatorSites {
-		site := operatorSite.ToSite()
-		sites = append(sites, *site)
-	}
-
-	// Add action sites
-	for _, actionSite := range result.ActionSites {
-		site := actionSite.ToSite()
-		sites = append(sites, *site)
+	if resp.Body != nil {
+		defer resp.Body.Close()
 	}
 
-	return sites, nil
+	// Decode the external, operator, action and custom sites one at a time, converting each to a Site model
+	return decodeXMLElements(resp.Body, map[string]elementHandler{
+		"ExternalSite": decodeElementHandler(func(site model.ExternalSite) bool { return fn(*site.ToSite()) }),
+		"OperatorSite": decodeElementHandler(func(site model.OperatorSite) bool { return fn(*site.ToSite()) }),
+		"ActionSite":   decodeElementHandler(func(site model.ActionSite) bool { return fn(*site.ToSite()) }),
+		"CustomSite":   decodeElementHandler(func(site model.CustomSite) bool { return fn(*site.ToSite()) }),
+	})
 }
 
 // Get retrieves a single site by name and type
-// The siteType should be one of: "external", "operator", "master" (for action site)
+// The siteType should be one of: "external", "operator", "master" (for action site), "custom"
 func (ss *SiteService) Get(ctx context.Context, name string, siteType string) (*model.Site, error) {
 	var endpoint string
 
@@ -86,13 +78,16 @@ func (ss *SiteService) Get(ctx context.Context, name string, siteType string) (*
 		endpoint = "/api/site/operator/" + url.PathEscape(name)
 	case "master", "action":
 		endpoint = "/api/site/master"
+	case "custom":
+		endpoint = "/api/site/custom/" + url.PathEscape(name)
 	default:
-		return nil, fmt.Errorf("invalid site type: %s. Must be one of: external, operator, master", siteType)
+		return nil, fmt.Errorf("invalid site type: %s. Must be one of: external, operator, master, custom", siteType)
 	}
 
 	// Perform the request with retry logic and limiter tag
-	resp, err := ss.client.executeWithRetryDefaultWithLimiter(func() (*resty.Response, error) {
+	resp, err := ss.client.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
 		return ss.client.Resty.R().
+			SetContext(ctx).
 			SetHeader("Accept", "application/xml").
 			Get(ss.client.BaseURL + ":" + strconv.Itoa(ss.client.PortNumber) + endpoint)
 	}, "bigfix_site_get")
@@ -132,16 +127,25 @@ func (ss *SiteService) Get(ctx context.Context, name string, siteType string) (*
 		if result.ActionSite != nil {
 			site = result.ActionSite.ToSite()
 		}
+	case "custom":
+		if result.CustomSite != nil {
+			site = result.CustomSite.ToSite()
+		}
 	}
 
 	if site == nil {
-		return nil, fmt.Errorf("site %s (%s) not found", name, siteType)
+		return nil, &Error{
+			StatusCode: http.StatusNotFound,
+			Endpoint:   endpoint,
+			Message:    fmt.Sprintf("site %s (%s) not found", name, siteType),
+			Class:      ErrorClassNotFound,
+		}
 	}
 
 	// Set the resource URL for the site
 	site.Resource = ss.client.BaseURL + ":" + strconv.Itoa(ss.client.PortNumber) + endpoint
 
-	plugin.Logger(ctx).Debug("API response site:", site)
+	ss.client.logger.Debug("API response", "site", site)
 
 	return site, nil
 }
@@ -160,13 +164,16 @@ func (ss *SiteService) GetPermissions(ctx context.Context, name string, siteType
 	case "action":
 		// Action sites might not have permissions endpoint, but let's try the action pattern
 		endpoint = "/api/site/action/" + url.PathEscape(name) + "/permissions"
+	case "custom":
+		endpoint = "/api/site/custom/" + url.PathEscape(name) + "/permissions"
 	default:
-		return nil, fmt.Errorf("invalid site type: %s. Must be one of: external, operator, master, action", siteType)
+		return nil, fmt.Errorf("invalid site type: %s. Must be one of: external, operator, master, action, custom", siteType)
 	}
 
 	// Perform the request with retry logic and limiter tag
-	resp, err := ss.client.executeWithRetryDefaultWithLimiter(func() (*resty.Response, error) {
+	resp, err := ss.client.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
 		return ss.client.Resty.R().
+			SetContext(ctx).
 			SetHeader("Accept", "application/xml").
 			Get(ss.client.BaseURL + ":" + strconv.Itoa(ss.client.PortNumber) + endpoint)
 	}, "bigfix_site_permissions")
@@ -191,7 +198,7 @@ func (ss *SiteService) GetPermissions(ctx context.Context, name string, siteType
 		return nil, fmt.Errorf("failed to parse XML response: %w", err)
 	}
 
-	plugin.Logger(ctx).Debug("API response permissions:", result.Permissions)
+	ss.client.logger.Debug("API response", "permissions", result.Permissions)
 
 	return result.Permissions, nil
 }
@@ -209,13 +216,16 @@ func (ss *SiteService) GetFiles(ctx context.Context, name string, siteType strin
 		endpoint = "/api/site/master/files"
 	case "action":
 		endpoint = "/api/site/action/" + url.PathEscape(name) + "/files"
+	case "custom":
+		endpoint = "/api/site/custom/" + url.PathEscape(name) + "/files"
 	default:
-		return nil, fmt.Errorf("invalid site type: %s. Must be one of: external, operator, master, action", siteType)
+		return nil, fmt.Errorf("invalid site type: %s. Must be one of: external, operator, master, action, custom", siteType)
 	}
 
 	// Perform the request with retry logic and limiter tag
-	resp, err := ss.client.executeWithRetryDefaultWithLimiter(func() (*resty.Response, error) {
+	resp, err := ss.client.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
 		return ss.client.Resty.R().
+			SetContext(ctx).
 			SetHeader("Accept", "application/xml").
 			Get(ss.client.BaseURL + ":" + strconv.Itoa(ss.client.PortNumber) + endpoint)
 	}, "bigfix_site_files")
@@ -240,7 +250,7 @@ func (ss *SiteService) GetFiles(ctx context.Context, name string, siteType strin
 		return nil, fmt.Errorf("failed to parse XML response: %w", err)
 	}
 
-	plugin.Logger(ctx).Debug("API response files:", result.Files)
+	ss.client.logger.Debug("API response", "files", result.Files)
 
 	return result.Files, nil
 }
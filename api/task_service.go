@@ -4,12 +4,10 @@ import (
 	"context"
 	"encoding/xml"
 	"fmt"
-	"io"
 	"net/url"
 	"strconv"
 
 	"github.com/turbot/steampipe-plugin-bigfix/api/model"
-	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"resty.dev/v3"
 )
 
@@ -27,6 +25,22 @@ func NewTaskService(client *Client) *TaskService {
 
 // List retrieves all tasks for a specific site
 func (ts *TaskService) List(ctx context.Context, siteName string, siteType string) ([]model.Task, error) {
+	var tasks []model.Task
+	err := ts.ListFunc(ctx, siteName, siteType, func(task model.Task) bool {
+		tasks = append(tasks, task)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ts.client.logger.Debug("API response", "tasks", tasks)
+
+	return tasks, nil
+}
+
+// ListFunc streams the tasks of the site to fn as they are decoded from the response, stopping as soon as fn returns false
+func (ts *TaskService) ListFunc(ctx context.Context, siteName string, siteType string, fn func(model.Task) bool) error {
 	var endpoint string
 
 	switch siteType {
@@ -38,50 +52,79 @@ func (ts *TaskService) List(ctx context.Context, siteName string, siteType strin
 		endpoint = "/api/tasks/master"
 	case "action":
 		endpoint = "/api/tasks/action/" + url.PathEscape(siteName)
+	case "custom":
+		endpoint = "/api/tasks/custom/" + url.PathEscape(siteName)
 	default:
-		return nil, fmt.Errorf("invalid site type: %s. Must be one of: external, operator, master, action", siteType)
+		return fmt.Errorf("invalid site type: %s. Must be one of: external, operator, master, action, custom", siteType)
 	}
 
 	// Perform the request with retry logic and limiter tag
-	resp, err := ts.client.executeWithRetryDefaultWithLimiter(func() (*resty.Response, error) {
+	resp, err := ts.client.executeWithRetryDefaultWithLimiter(ctx, func() (*resty.Response, error) {
 		return ts.client.Resty.R().
+			SetContext(ctx).
 			SetHeader("Accept", "application/xml").
 			Get(ts.client.BaseURL + ":" + strconv.Itoa(ts.client.PortNumber) + endpoint)
 	}, "bigfix_task_list")
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch tasks for site %s (%s): %w", siteName, siteType, err)
+		return fmt.Errorf("failed to fetch tasks for site %s (%s): %w", siteName, siteType, err)
 	}
 
 	if resp.Body != nil {
 		defer resp.Body.Close()
 	}
 
-	// Read the response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
+	// Decode the tasks one at a time, setting the site information on each
+	return decodeXMLList(resp.Body, "Task", func(task model.Task) bool {
+		task.SiteName = siteName
+		task.SiteType = siteType
+		return fn(task)
+	})
+}
 
-	// Parse XML for tasks response
-	var result model.TaskListResponse
-	if err := xml.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse XML response: %w", err)
+// ListDetails retrieves the title, category, source severity and source release date of all the tasks
+// of a site with a single session relevance query, keyed by task ID. This is much faster than calling
+// Get for each task of large sites. The relevance is left out, as session relevance only returns the
+// clauses of a task combined into a single expression.
+func (ts *TaskService) ListDetails(ctx context.Context, siteName string, siteType string) (map[int]model.Task, error) {
+	rows, err := ts.client.querySiteContent(ctx, siteName, siteType, taskFlag, []string{
+		"name of it",
+		"category of it",
+		"source severity of it",
+		"source release date of it as string",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch task details for site %s (%s): %w", siteName, siteType, err)
 	}
 
-	// Set site information for each task
-	for i := range result.Tasks {
-		result.Tasks[i].SiteName = siteName
-		result.Tasks[i].SiteType = siteType
+	tasks := make(map[int]model.Task, len(rows))
+	for id, row := range rows {
+		tasks[id] = model.Task{
+			ID:                id,
+			Name:              row[0],
+			SiteName:          siteName,
+			SiteType:          siteType,
+			Title:             row[0],
+			Category:          row[1],
+			SourceSeverity:    row[2],
+			SourceReleaseDate: relevanceDate(row[3]),
+			DetailsFetched:    true,
+		}
 	}
 
-	plugin.Logger(ctx).Debug("API response tasks:", result.Tasks)
+	ts.client.logger.Debug("API response", "task_details", len(tasks))
 
-	return result.Tasks, nil
+	return tasks, nil
 }
 
 // Get retrieves a specific task detail
 func (ts *TaskService) Get(ctx context.Context, siteName string, siteType string, taskID int) (*model.Task, error) {
+	return ts.GetCached(ctx, siteName, siteType, taskID, "")
+}
+
+// GetCached retrieves a specific task detail like Get. When lastModified is set, as returned for the task
+// in list responses, the detail is served from the disk cache of the client if it did not change.
+func (ts *TaskService) GetCached(ctx context.Context, siteName string, siteType string, taskID int, lastModified string) (*model.Task, error) {
 	var endpoint string
 
 	switch siteType {
@@ -93,31 +136,18 @@ func (ts *TaskService) Get(ctx context.Context, siteName string, siteType string
 		endpoint = "/api/task/master/" + strconv.Itoa(taskID)
 	case "action":
 		endpoint = "/api/task/action/" + url.PathEscape(siteName) + "/" + strconv.Itoa(taskID)
+	case "custom":
+		endpoint = "/api/task/custom/" + url.PathEscape(siteName) + "/" + strconv.Itoa(taskID)
 	default:
-		return nil, fmt.Errorf("invalid site type: %s. Must be one of: external, operator, master, action", siteType)
+		return nil, fmt.Errorf("invalid site type: %s. Must be one of: external, operator, master, action, custom", siteType)
 	}
 
-	// Perform the request with retry logic and limiter tag
-	resp, err := ts.client.executeWithRetryDefaultWithLimiter(func() (*resty.Response, error) {
-		return ts.client.Resty.R().
-			SetHeader("Accept", "application/xml").
-			Get(ts.client.BaseURL + ":" + strconv.Itoa(ts.client.PortNumber) + endpoint)
-	}, "bigfix_task_get")
-
+	// Fetch the detail, from the disk cache when unchanged
+	body, err := ts.client.getDetail(ctx, endpoint, lastModified, "bigfix_task_get")
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch task %d for site %s (%s): %w", taskID, siteName, siteType, err)
 	}
 
-	if resp.Body != nil {
-		defer resp.Body.Close()
-	}
-
-	// Read the response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
 	// Parse XML for task detail response
 	var result model.TaskDetailResponse
 	if err := xml.Unmarshal(body, &result); err != nil {
@@ -128,7 +158,7 @@ func (ts *TaskService) Get(ctx context.Context, siteName string, siteType string
 	resourceURL := ts.client.BaseURL + ":" + strconv.Itoa(ts.client.PortNumber) + endpoint
 	task := result.Task.ToTask(taskID, resourceURL, siteName, siteType)
 
-	plugin.Logger(ctx).Debug("API response task:", task)
+	ts.client.logger.Debug("API response", "task", task)
 
 	return task, nil
 }
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+)
+
+// TLSOptions defines how the client verifies the BigFix server and authenticates to it over TLS
+type TLSOptions struct {
+	// InsecureSkipVerify disables the verification of the server certificate
+	InsecureSkipVerify bool
+	// CACertPEM holds PEM encoded CA certificates trusted in addition to the system roots
+	CACertPEM []byte
+	// ClientCertPEM and ClientKeyPEM hold the PEM encoded client certificate and private key used for mutual TLS
+	ClientCertPEM []byte
+	ClientKeyPEM  []byte
+	// ServerName overrides the host name used to verify the server certificate
+	ServerName string
+	// MinVersion is the minimum TLS version accepted, e.g. tls.VersionTLS12. Zero uses the Go default.
+	MinVersion uint16
+}
+
+// tlsVersions maps the supported minimum TLS versions by name
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// ParseTLSVersion returns the TLS version for a version name such as "1.2"
+func ParseTLSVersion(version string) (uint16, error) {
+	if v, ok := tlsVersions[version]; ok {
+		return v, nil
+	}
+	return 0, fmt.Errorf("invalid TLS version: %s. Must be one of: 1.0, 1.1, 1.2, 1.3", version)
+}
+
+// Config builds the tls.Config used by the client from the options
+func (o TLSOptions) Config() (*tls.Config, error) {
+	config := &tls.Config{
+		InsecureSkipVerify: o.InsecureSkipVerify,
+		ServerName:         o.ServerName,
+		MinVersion:         o.MinVersion,
+	}
+
+	if len(o.CACertPEM) > 0 {
+		// Trust the given CAs on top of the system roots, so public certificates keep working
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(o.CACertPEM) {
+			return nil, fmt.Errorf("no valid PEM encoded CA certificate found")
+		}
+		config.RootCAs = pool
+	}
+
+	if len(o.ClientCertPEM) > 0 || len(o.ClientKeyPEM) > 0 {
+		if len(o.ClientCertPEM) == 0 || len(o.ClientKeyPEM) == 0 {
+			return nil, fmt.Errorf("both a client certificate and a client key are required for mutual TLS")
+		}
+		cert, err := tls.X509KeyPair(o.ClientCertPEM, o.ClientKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate or key: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// errStopDecoding is returned by element handlers to end decoding early without an error
+var errStopDecoding = errors.New("stop decoding")
+
+// elementHandler decodes a single child element of the root element of a response
+type elementHandler func(decoder *xml.Decoder, start xml.StartElement) error
+
+// decodeXMLElements walks the direct children of the root element of an XML document,
+// passing each one with a registered handler to that handler and skipping the others.
+// Only one element is held in memory at a time, so large list responses are never buffered.
+func decodeXMLElements(r io.Reader, handlers map[string]elementHandler) error {
+	decoder := xml.NewDecoder(r)
+	depth := 0
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to parse XML response: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			// Root element, descend into its children
+			if depth == 0 {
+				depth++
+				continue
+			}
+
+			handler, ok := handlers[t.Name.Local]
+			if !ok {
+				if err := decoder.Skip(); err != nil {
+					return fmt.Errorf("failed to parse XML response: %w", err)
+				}
+				continue
+			}
+
+			if err := handler(decoder, t); err != nil {
+				if errors.Is(err, errStopDecoding) {
+					return nil
+				}
+				return err
+			}
+		case xml.EndElement:
+			depth--
+		}
+	}
+}
+
+// decodeXMLList decodes the elements with the given name from a list response one at a time,
+// calling fn for each of them. Decoding stops as soon as fn returns false.
+func decodeXMLList[T any](r io.Reader, elementName string, fn func(T) bool) error {
+	return decodeXMLElements(r, map[string]elementHandler{
+		elementName: decodeElementHandler(fn),
+	})
+}
+
+// decodeElementHandler returns an elementHandler that decodes the element into a T and passes it to fn
+func decodeElementHandler[T any](fn func(T) bool) elementHandler {
+	return func(decoder *xml.Decoder, start xml.StartElement) error {
+		var item T
+		if err := decoder.DecodeElement(&item, &start); err != nil {
+			return fmt.Errorf("failed to parse XML response: %w", err)
+		}
+		if !fn(item) {
+			return errStopDecoding
+		}
+		return nil
+	}
+}
@@ -11,9 +11,45 @@ type BigFixConfig struct {
 	Port                *int     `hcl:"port,optional"`
 	UserName            *string  `hcl:"user_name,optional"`
 	Password            *string  `hcl:"password,optional"`
+	PasswordFile        *string  `hcl:"password_file,optional"`
+	AuthMode            *string  `hcl:"auth_mode,optional"`
 	IgnoreErrorMessages []string `hcl:"ignore_error_messages,optional"`
+	IgnoreErrorCodes    []int    `hcl:"ignore_error_codes,optional"`
 	InsecureSkipVerify  *bool    `hcl:"insecure_skip_verify,optional"`
+	CACertPath          *string  `hcl:"ca_cert_path,optional"`
+	CACertPEM           *string  `hcl:"ca_cert_pem,optional"`
+	ClientCertPath      *string  `hcl:"client_cert_path,optional"`
+	ClientKeyPath       *string  `hcl:"client_key_path,optional"`
+	TLSServerName       *string  `hcl:"tls_server_name,optional"`
+	MinTLSVersion       *string  `hcl:"min_tls_version,optional"`
 	RequestTimeout      *int64   `hcl:"request_timeout,optional"`
+	MaxIdleConnsPerHost *int     `hcl:"max_idle_conns_per_host,optional"`
+
+	// HTTP
+	ProxyURL  *string           `hcl:"proxy_url,optional"`
+	NoProxy   []string          `hcl:"no_proxy,optional"`
+	Headers   map[string]string `hcl:"headers,optional"`
+	UserAgent *string           `hcl:"user_agent,optional"`
+
+	// Sites
+	MaxSiteConcurrency *int     `hcl:"max_site_concurrency,optional"`
+	ExcludeSites       []string `hcl:"exclude_sites,optional"`
+	SkipFailedSites    *bool    `hcl:"skip_failed_sites,optional"`
+
+	// Disk cache
+	CacheDir     *string `hcl:"cache_dir,optional"`
+	CacheMaxSize *int64  `hcl:"cache_max_size,optional"`
+	CachePurge   *bool   `hcl:"cache_purge,optional"`
+
+	// Dynamic columns
+	ComputerProperties    []string          `hcl:"computer_properties,optional"`
+	ComputerPropertyTypes map[string]string `hcl:"computer_property_types,optional"`
+
+	// Rate limiting
+	RequestsPerSecond    *float64           `hcl:"requests_per_second,optional"`
+	MaxConcurrency       *int               `hcl:"max_concurrency,optional"`
+	TagRequestsPerSecond map[string]float64 `hcl:"tag_requests_per_second,optional"`
+	TagMaxConcurrency    map[string]int     `hcl:"tag_max_concurrency,optional"`
 }
 
 func ConfigInstance() interface{} {
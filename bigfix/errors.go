@@ -2,33 +2,35 @@ package bigfix
 
 import (
 	"context"
+	"slices"
 	"strings"
 
+	"github.com/turbot/steampipe-plugin-bigfix/api"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 )
 
 // shouldIgnoreErrors returns an ErrorPredicate for BigFix API calls
 // Following the same pattern as AWS plugin
-// This function combines default "not found" errors with any additional
-// error messages specified in the configuration's ignore_error_messages parameter
-func shouldIgnoreErrors(notFoundErrors []string) plugin.ErrorPredicateWithContext {
+// This function ignores API errors whose HTTP status code is one of the given status codes
+// or is listed in the configuration's ignore_error_codes parameter, as well as errors whose
+// message matches the configuration's ignore_error_messages parameter
+func shouldIgnoreErrors(statusCodes []int) plugin.ErrorPredicateWithContext {
 	return func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, err error) bool {
 		if err == nil {
 			return false
 		}
 
-		errorStr := strings.ToLower(err.Error())
-
-		// Get configuration to check for additional ignore error messages
+		// Get configuration to check for additional ignore error codes and messages
 		config := GetConfig(d.Connection)
 
-		// Combine default "not found" errors with configured ignore error messages
-		allIgnorePatterns := append([]string{}, notFoundErrors...)
-		if config.IgnoreErrorMessages != nil {
-			allIgnorePatterns = append(allIgnorePatterns, config.IgnoreErrorMessages...)
+		if statusCode := api.StatusCode(err); statusCode != 0 {
+			if slices.Contains(statusCodes, statusCode) || slices.Contains(config.IgnoreErrorCodes, statusCode) {
+				return true
+			}
 		}
 
-		for _, pattern := range allIgnorePatterns {
+		errorStr := strings.ToLower(err.Error())
+		for _, pattern := range config.IgnoreErrorMessages {
 			if strings.Contains(errorStr, strings.ToLower(pattern)) {
 				return true
 			}
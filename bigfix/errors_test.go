@@ -0,0 +1,40 @@
+package bigfix
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-bigfix/api"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+func TestShouldIgnoreErrors(t *testing.T) {
+	notFound := fmt.Errorf("failed to fetch baselines: %w", &api.Error{StatusCode: http.StatusNotFound, Class: api.ErrorClassNotFound})
+	forbidden := fmt.Errorf("failed to fetch role: %w", &api.Error{StatusCode: http.StatusForbidden, Class: api.ErrorClassForbidden, Message: "Operator does not have permission"})
+
+	tests := []struct {
+		name   string
+		config BigFixConfig
+		err    error
+		want   bool
+	}{
+		{name: "no error", err: nil, want: false},
+		{name: "default status code", err: notFound, want: true},
+		{name: "other status code", err: forbidden, want: false},
+		{name: "ignore_error_codes", config: BigFixConfig{IgnoreErrorCodes: []int{http.StatusForbidden}}, err: forbidden, want: true},
+		{name: "ignore_error_messages", config: BigFixConfig{IgnoreErrorMessages: []string{"does not have PERMISSION"}}, err: forbidden, want: true},
+		{name: "ignore_error_messages without match", config: BigFixConfig{IgnoreErrorMessages: []string{"timeout"}}, err: forbidden, want: false},
+		{name: "network error", err: errors.New("connection refused"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &plugin.QueryData{Connection: &plugin.Connection{Name: "bigfix", Config: tt.config}}
+			if got := shouldIgnoreErrors([]int{http.StatusNotFound})(testContext(), d, nil, tt.err); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
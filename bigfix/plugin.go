@@ -17,15 +17,35 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 		ConnectionConfigSchema: &plugin.ConnectionConfigSchema{
 			NewInstance: ConfigInstance,
 		},
-		TableMap: map[string]*plugin.Table{
-			"bigfix_action":   tableBigFixAction(ctx),
-			"bigfix_analysis": tableBigFixAnalysis(ctx),
-			"bigfix_computer": tableBigFixComputer(ctx),
-			"bigfix_fixlet":   tableBigFixFixlet(ctx),
-			"bigfix_property": tableBigFixProperty(ctx),
-			"bigfix_role":     tableBigFixRole(ctx),
-			"bigfix_site":     tableBigFixSite(ctx),
-			"bigfix_task":     tableBigFixTask(ctx),
-		},
+		// The columns of bigfix_computer depend on the retrieved properties of the BigFix server
+		SchemaMode:   plugin.SchemaModeDynamic,
+		TableMapFunc: pluginTableDefinitions,
 	}
 }
+
+// pluginTableDefinitions returns the tables of a connection
+func pluginTableDefinitions(ctx context.Context, td *plugin.TableMapData) (map[string]*plugin.Table, error) {
+	computerTable, err := tableBigFixComputer(ctx, td)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]*plugin.Table{
+		"bigfix_action":                tableBigFixAction(ctx),
+		"bigfix_action_member":         tableBigFixActionMember(ctx),
+		"bigfix_action_status":         tableBigFixActionStatus(ctx),
+		"bigfix_analysis":              tableBigFixAnalysis(ctx),
+		"bigfix_baseline":              tableBigFixBaseline(ctx),
+		"bigfix_computer":              computerTable,
+		"bigfix_computer_group":        tableBigFixComputerGroup(ctx),
+		"bigfix_computer_group_member": tableBigFixComputerGroupMember(ctx),
+		"bigfix_fixlet":                tableBigFixFixlet(ctx),
+		"bigfix_operator":              tableBigFixOperator(ctx),
+		"bigfix_property":              tableBigFixProperty(ctx),
+		"bigfix_query":                 tableBigFixQuery(ctx),
+		"bigfix_role":                  tableBigFixRole(ctx),
+		"bigfix_role_site_permission":  tableBigFixRoleSitePermission(ctx),
+		"bigfix_site":                  tableBigFixSite(ctx),
+		"bigfix_task":                  tableBigFixTask(ctx),
+	}, nil
+}
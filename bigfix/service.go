@@ -2,54 +2,368 @@ package bigfix
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/turbot/steampipe-plugin-bigfix/api"
+	"github.com/turbot/steampipe-plugin-sdk/v5/connection"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 )
 
+// clientMutex serialises the creation of connection clients, so that the concurrent hydrate calls of a
+// query do not each create their own client
+var clientMutex sync.Mutex
+
+// clientCacheTTL is how long the client of a connection stays in the connection cache. Steampipe clears the
+// cache of a connection when its config changes, and the client of a deleted connection expires after
+// this TTL. The keep-alive connections of a client that is no longer cached are closed once idle.
+const clientCacheTTL = time.Hour
+
+// purgedCacheDirs holds the cache directories already purged by this plugin process, so that cache_purge
+// empties a directory once rather than every time a client of the connection is created
+var purgedCacheDirs sync.Map
+
+// NewService returns the BigFix API client of the connection.
+// The client is created once per connection config, so that every list and get hydrate call reuses
+// the same keep-alive connections to the BigFix server and the same rate limiter.
 func NewService(ctx context.Context, d *plugin.QueryData) (*api.Client, error) {
-	config := GetConfig(d.Connection)
+	return connectionClient(ctx, d.Connection, d.ConnectionCache)
+}
 
-	if config.ServerName == nil {
-		return nil, fmt.Errorf("server_name is required")
+// connectionClient returns the BigFix API client of a connection from the connection cache, creating it
+// if needed. The cache key includes the connection config, so a config change never reuses the client
+// of the previous config.
+func connectionClient(ctx context.Context, conn *plugin.Connection, cache *connection.ConnectionCache) (*api.Client, error) {
+	config := GetConfig(conn)
+
+	cacheKey, err := clientConfigKey(config)
+	if err != nil {
+		return nil, err
 	}
 
-	if config.UserName == nil {
-		return nil, fmt.Errorf("user_name is required")
+	clientMutex.Lock()
+	defer clientMutex.Unlock()
+
+	if cached, ok := cache.Get(ctx, cacheKey); ok {
+		return cached.(*api.Client), nil
 	}
 
-	if config.Password == nil {
-		return nil, fmt.Errorf("password is required")
+	client, err := newClient(ctx, config)
+	if err != nil {
+		return nil, err
 	}
 
-	if config.Port == nil {
-		return nil, fmt.Errorf("port is required")
+	if err := cache.SetWithTTL(ctx, cacheKey, client, clientCacheTTL); err != nil {
+		plugin.Logger(ctx).Warn("connectionClient", "cache_error", err)
 	}
 
-	// Default insecure_skip_verify to false if not specified
-	insecureSkipVerify := false
-	if config.InsecureSkipVerify != nil {
-		insecureSkipVerify = *config.InsecureSkipVerify
+	return client, nil
+}
+
+// clientConfigKey returns a key that changes whenever the connection config changes
+func clientConfigKey(config BigFixConfig) (string, error) {
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute client config key: %w", err)
+	}
+	hash := sha256.Sum256(configJSON)
+	return "bigfix_client_" + hex.EncodeToString(hash[:]), nil
+}
+
+// newClient creates a BigFix API client from the connection config
+func newClient(ctx context.Context, config BigFixConfig) (*api.Client, error) {
+	connectionConfig, err := getConnectionConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Default auth_mode to basic if not specified
+	authMode := api.AuthModeBasic
+	if config.AuthMode != nil {
+		authMode = *config.AuthMode
+	}
+	if authMode != api.AuthModeBasic && authMode != api.AuthModeSession {
+		return nil, fmt.Errorf("invalid auth_mode: %s. Must be one of: %s, %s", authMode, api.AuthModeBasic, api.AuthModeSession)
+	}
+
+	tlsConfig, err := getTLSConfig(config)
+	if err != nil {
+		return nil, err
 	}
 
 	// Default request timeout to 120 seconds if not specified
-	requestTimeout := 120 * time.Second
+	requestTimeout := api.DefaultTimeout
 	if config.RequestTimeout != nil {
 		requestTimeout = time.Duration(*config.RequestTimeout) * time.Second
 	}
 
-	client := api.NewClient(*config.ServerName, *config.UserName, *config.Password, *config.Port, insecureSkipVerify, requestTimeout)
-
-	// Apply configuration overrides
+	// Apply retry configuration overrides
+	retryPolicy := api.DefaultRetryPolicy
 	if config.MaxRetries != nil {
-		client = client.WithMaxRetries(*config.MaxRetries)
+		if *config.MaxRetries < 1 {
+			return nil, fmt.Errorf("invalid max_retries: %d. Must be at least 1", *config.MaxRetries)
+		}
+		retryPolicy.MaxAttempts = *config.MaxRetries
 	}
-
 	if config.MinRetryDelay != nil {
-		client = client.WithMinDelay(time.Duration(*config.MinRetryDelay) * time.Millisecond)
+		retryPolicy.MinDelay = time.Duration(*config.MinRetryDelay) * time.Millisecond
+	}
+
+	client, err := api.New(connectionConfig.ServerName,
+		api.WithPort(connectionConfig.Port),
+		api.WithBasicAuth(connectionConfig.UserName, connectionConfig.Password),
+		api.WithTLSConfig(tlsConfig),
+		api.WithTimeout(requestTimeout),
+		api.WithRetryPolicy(retryPolicy),
+		// The client outlives the query creating it, so it logs with the plugin logger rather than the
+		// logger of that query, which is named after its call id
+		api.WithLogger(plugin.Logger(ctx).ResetNamed(pluginName)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if authMode == api.AuthModeSession {
+		client = client.WithSessionAuth()
+	}
+
+	// Default max idle connections per host to 10 if not specified
+	maxIdleConnsPerHost := 10
+	if config.MaxIdleConnsPerHost != nil {
+		maxIdleConnsPerHost = *config.MaxIdleConnsPerHost
+	}
+	client = client.WithMaxIdleConnsPerHost(maxIdleConnsPerHost)
+
+	if config.ProxyURL != nil {
+		if err := validateProxyURL(*config.ProxyURL); err != nil {
+			return nil, err
+		}
+		client = client.WithProxy(*config.ProxyURL, strings.Join(config.NoProxy, ","))
+	}
+
+	if len(config.Headers) > 0 {
+		client = client.WithHeaders(config.Headers)
+	}
+
+	// Default the user agent to the plugin name and version if not specified
+	userAgent := defaultUserAgent()
+	if config.UserAgent != nil {
+		userAgent = *config.UserAgent
+	}
+	client = client.WithUserAgent(userAgent)
+
+	if rateLimitConfig := getRateLimitConfig(config); !rateLimitConfig.IsZero() {
+		client = client.WithRateLimiter(api.NewRateLimiter(rateLimitConfig))
+	}
+
+	if config.CacheDir != nil {
+		cache, err := getDiskCache(config)
+		if err != nil {
+			return nil, err
+		}
+		client = client.WithDiskCache(cache)
 	}
 
 	return client, nil
 }
+
+// getDiskCache returns the disk cache of the connection, storing up to cache_max_size MB in cache_dir.
+// When cache_purge is set, the cache is emptied the first time it is opened by the plugin process.
+func getDiskCache(config BigFixConfig) (*api.DiskCache, error) {
+	dir := *config.CacheDir
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to expand cache_dir: %w", err)
+		}
+		dir = filepath.Join(home, strings.TrimPrefix(dir, "~"))
+	}
+
+	// Default cache max size to 1024 MB if not specified
+	maxSize := int64(1024)
+	if config.CacheMaxSize != nil {
+		maxSize = *config.CacheMaxSize
+	}
+	if maxSize <= 0 {
+		return nil, fmt.Errorf("invalid cache_max_size: %d. Must be greater than 0", maxSize)
+	}
+
+	cache, err := api.NewDiskCache(dir, maxSize*1024*1024)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cache_dir: %w", err)
+	}
+
+	if config.CachePurge != nil && *config.CachePurge {
+		if _, purged := purgedCacheDirs.LoadOrStore(filepath.Clean(dir), true); !purged {
+			if err := cache.Purge(); err != nil {
+				purgedCacheDirs.Delete(filepath.Clean(dir))
+				return nil, err
+			}
+		}
+	}
+
+	return cache, nil
+}
+
+// getConnectionConfig returns the server and credentials of the connection. Settings missing from the
+// connection config fall back to the BIGFIX_SERVER_NAME, BIGFIX_PORT, BIGFIX_USER_NAME and BIGFIX_PASSWORD
+// environment variables, and the port defaults to 52311.
+func getConnectionConfig(config BigFixConfig) (api.Config, error) {
+	var connectionConfig api.Config
+
+	if config.ServerName != nil {
+		connectionConfig.ServerName = *config.ServerName
+	}
+	if config.Port != nil {
+		connectionConfig.Port = *config.Port
+	}
+	if config.UserName != nil {
+		connectionConfig.UserName = *config.UserName
+	}
+
+	if config.Password != nil && config.PasswordFile != nil {
+		return api.Config{}, fmt.Errorf("password and password_file cannot both be set")
+	}
+	if config.Password != nil {
+		connectionConfig.Password = *config.Password
+	}
+	if config.PasswordFile != nil {
+		password, err := os.ReadFile(*config.PasswordFile)
+		if err != nil {
+			return api.Config{}, fmt.Errorf("failed to read password_file: %w", err)
+		}
+		// Editors usually end files with a newline, which is never part of the password
+		connectionConfig.Password = strings.TrimRight(string(password), "\r\n")
+	}
+
+	connectionConfig, err := connectionConfig.WithEnvDefaults()
+	if err != nil {
+		return api.Config{}, err
+	}
+
+	if connectionConfig.ServerName == "" {
+		return api.Config{}, fmt.Errorf("server_name is required, set it in the connection config or with the %s environment variable", api.EnvServerName)
+	}
+	if connectionConfig.UserName == "" {
+		return api.Config{}, fmt.Errorf("user_name is required, set it in the connection config or with the %s environment variable", api.EnvUserName)
+	}
+	if connectionConfig.Password == "" {
+		return api.Config{}, fmt.Errorf("password is required, set it or password_file in the connection config or with the %s environment variable", api.EnvPassword)
+	}
+	if err := connectionConfig.Validate(); err != nil {
+		return api.Config{}, err
+	}
+
+	return connectionConfig, nil
+}
+
+// getRateLimitConfig returns the rate limits configured for the connection
+func getRateLimitConfig(config BigFixConfig) api.RateLimitConfig {
+	rateLimitConfig := api.RateLimitConfig{
+		TagRequestsPerSecond: config.TagRequestsPerSecond,
+		TagMaxConcurrency:    config.TagMaxConcurrency,
+	}
+	if config.RequestsPerSecond != nil {
+		rateLimitConfig.RequestsPerSecond = *config.RequestsPerSecond
+	}
+	if config.MaxConcurrency != nil {
+		rateLimitConfig.MaxConcurrency = *config.MaxConcurrency
+	}
+	return rateLimitConfig
+}
+
+// getTLSConfig returns the TLS settings configured for the connection
+func getTLSConfig(config BigFixConfig) (*tls.Config, error) {
+	var tlsOptions api.TLSOptions
+
+	// Default insecure_skip_verify to false if not specified
+	if config.InsecureSkipVerify != nil {
+		tlsOptions.InsecureSkipVerify = *config.InsecureSkipVerify
+	}
+
+	if config.CACertPath != nil && config.CACertPEM != nil {
+		return nil, fmt.Errorf("ca_cert_path and ca_cert_pem cannot both be set")
+	}
+	if config.CACertPath != nil {
+		caCertPEM, err := os.ReadFile(*config.CACertPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ca_cert_path: %w", err)
+		}
+		tlsOptions.CACertPEM = caCertPEM
+	}
+	if config.CACertPEM != nil {
+		tlsOptions.CACertPEM = []byte(*config.CACertPEM)
+	}
+
+	if (config.ClientCertPath == nil) != (config.ClientKeyPath == nil) {
+		return nil, fmt.Errorf("client_cert_path and client_key_path must be set together")
+	}
+	if config.ClientCertPath != nil {
+		clientCertPEM, err := os.ReadFile(*config.ClientCertPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client_cert_path: %w", err)
+		}
+		clientKeyPEM, err := os.ReadFile(*config.ClientKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client_key_path: %w", err)
+		}
+		tlsOptions.ClientCertPEM = clientCertPEM
+		tlsOptions.ClientKeyPEM = clientKeyPEM
+	}
+
+	if config.TLSServerName != nil {
+		tlsOptions.ServerName = *config.TLSServerName
+	}
+
+	if config.MinTLSVersion != nil {
+		minVersion, err := api.ParseTLSVersion(*config.MinTLSVersion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid min_tls_version: %w", err)
+		}
+		tlsOptions.MinVersion = minVersion
+	}
+
+	tlsConfig, err := tlsOptions.Config()
+	if err != nil {
+		return nil, fmt.Errorf("invalid TLS configuration: %w", err)
+	}
+
+	return tlsConfig, nil
+}
+
+// validateProxyURL returns an error if proxy_url is not an absolute http, https or socks5 URL
+func validateProxyURL(proxyURL string) error {
+	// The parse error is not returned as it would include the proxy credentials
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return fmt.Errorf("invalid proxy_url: not a valid URL")
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return fmt.Errorf("invalid proxy_url: scheme must be one of: http, https, socks5")
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid proxy_url: missing host")
+	}
+	return nil
+}
+
+// defaultUserAgent identifies the plugin and its version to the BigFix server
+func defaultUserAgent() string {
+	version := "dev"
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		version = strings.TrimPrefix(info.Main.Version, "v")
+	}
+	return pluginName + "/" + version
+}
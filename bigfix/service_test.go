@@ -0,0 +1,200 @@
+package bigfix
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/turbot/steampipe-plugin-bigfix/api"
+	"github.com/turbot/steampipe-plugin-sdk/v5/connection"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/context_key"
+)
+
+// testContext returns a context carrying the logger the plugin expects
+func testContext() context.Context {
+	return context.WithValue(context.Background(), context_key.Logger, hclog.NewNullLogger())
+}
+
+func testConnection(name string, config BigFixConfig) *plugin.Connection {
+	if config.ServerName == nil {
+		config.ServerName = ptr("bigfix.example.com")
+	}
+	if config.UserName == nil {
+		config.UserName = ptr("user")
+	}
+	if config.Password == nil {
+		config.Password = ptr("password")
+	}
+	return &plugin.Connection{Name: name, Config: config}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func testConnectionCache(t *testing.T, name string) *connection.ConnectionCache {
+	t.Helper()
+
+	cache, err := connection.NewConnectionCache(name, 1<<20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cache
+}
+
+func TestConnectionClient(t *testing.T) {
+	ctx := testContext()
+	cacheA := testConnectionCache(t, "bigfix_a")
+
+	conn := testConnection("bigfix_a", BigFixConfig{MaxConcurrency: ptr(2)})
+	client, err := connectionClient(ctx, conn, cacheA)
+	if err != nil {
+		t.Fatalf("connection client: %v", err)
+	}
+	if client.RateLimiter == nil {
+		t.Fatal("no rate limiter for max_concurrency")
+	}
+
+	// Every query of the connection shares the client and its rate limiter
+	again, err := connectionClient(ctx, testConnection("bigfix_a", BigFixConfig{MaxConcurrency: ptr(2)}), cacheA)
+	if err != nil {
+		t.Fatalf("connection client: %v", err)
+	}
+	if again != client || again.RateLimiter != client.RateLimiter {
+		t.Error("client not reused for the same connection config")
+	}
+
+	// Other connections get their own client
+	other, err := connectionClient(ctx, testConnection("bigfix_b", BigFixConfig{MaxConcurrency: ptr(2)}), testConnectionCache(t, "bigfix_b"))
+	if err != nil {
+		t.Fatalf("connection client: %v", err)
+	}
+	if other == client {
+		t.Error("client shared by two connections")
+	}
+
+	// A config change replaces the client of the connection
+	changed, err := connectionClient(ctx, testConnection("bigfix_a", BigFixConfig{MaxConcurrency: ptr(4)}), cacheA)
+	if err != nil {
+		t.Fatalf("connection client: %v", err)
+	}
+	if changed == client {
+		t.Error("client reused after a config change")
+	}
+
+	// Clearing the connection cache, as Steampipe does when the connection changes, drops the client
+	if err := cacheA.Clear(ctx); err != nil {
+		t.Fatal(err)
+	}
+	cleared, err := connectionClient(ctx, conn, cacheA)
+	if err != nil {
+		t.Fatalf("connection client: %v", err)
+	}
+	if cleared == client {
+		t.Error("client reused after the connection cache was cleared")
+	}
+
+	// Invalid configs are not cached
+	cacheC := testConnectionCache(t, "bigfix_c")
+	invalid := BigFixConfig{AuthMode: ptr("token")}
+	if _, err := connectionClient(ctx, testConnection("bigfix_c", invalid), cacheC); err == nil {
+		t.Error("no error for an invalid auth_mode")
+	}
+	key, err := clientConfigKey(testConnection("bigfix_c", invalid).Config.(BigFixConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cacheC.Get(ctx, key); ok {
+		t.Error("client cached for an invalid config")
+	}
+}
+
+func TestGetDiskCachePurge(t *testing.T) {
+	dir := t.TempDir()
+
+	cache, err := getDiskCache(BigFixConfig{CacheDir: ptr(dir)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.Put("key", "v1", []byte("one")); err != nil {
+		t.Fatal(err)
+	}
+
+	cache, err = getDiskCache(BigFixConfig{CacheDir: ptr(dir), CachePurge: ptr(false)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.Get("key", "v1"); !ok {
+		t.Fatal("the cache was purged without cache_purge")
+	}
+
+	cache, err = getDiskCache(BigFixConfig{CacheDir: ptr(dir), CachePurge: ptr(true)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.Get("key", "v1"); ok {
+		t.Error("the cache was not purged with cache_purge")
+	}
+
+	// The cache is only purged once by the plugin process
+	if err := cache.Put("key", "v2", []byte("two")); err != nil {
+		t.Fatal(err)
+	}
+	cache, err = getDiskCache(BigFixConfig{CacheDir: ptr(dir), CachePurge: ptr(true)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.Get("key", "v2"); !ok {
+		t.Error("the cache was purged again with cache_purge")
+	}
+}
+
+func TestNewClientMaxRetries(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxRetries *int
+		wantErr    bool
+	}{
+		{name: "default", maxRetries: nil, wantErr: false},
+		{name: "single attempt", maxRetries: ptr(1), wantErr: false},
+		{name: "no attempt", maxRetries: ptr(0), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testConnection("bigfix", BigFixConfig{MaxRetries: tt.maxRetries}).Config.(BigFixConfig)
+			_, err := newClient(testContext(), config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetConnectionConfigEnvPort(t *testing.T) {
+	t.Setenv(api.EnvPort, "not-a-port")
+
+	tests := []struct {
+		name     string
+		port     *int
+		wantPort int
+		wantErr  bool
+	}{
+		{name: "config port", port: ptr(8443), wantPort: 8443},
+		{name: "env port", port: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testConnection("bigfix", BigFixConfig{Port: tt.port}).Config.(BigFixConfig)
+			connectionConfig, err := getConnectionConfig(config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if err == nil && connectionConfig.Port != tt.wantPort {
+				t.Errorf("got port %d, want %d", connectionConfig.Port, tt.wantPort)
+			}
+		})
+	}
+}
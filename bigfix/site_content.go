@@ -0,0 +1,147 @@
+package bigfix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/turbot/steampipe-plugin-bigfix/api"
+	"github.com/turbot/steampipe-plugin-bigfix/api/model"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// siteDetailsCover reports whether all the columns selected by the query which need the detail of a row
+// are in covered, i.e. can be populated by the ListDetails query of the site instead of getting each row.
+// needed is false when no such column is selected.
+func siteDetailsCover(d *plugin.QueryData, covered map[string]bool) (covers bool, needed bool) {
+	detailColumns := map[string]bool{}
+	for _, column := range d.Table.Columns {
+		if column.Hydrate != nil {
+			detailColumns[column.Name] = true
+		}
+	}
+
+	for _, name := range d.QueryContext.Columns {
+		if !detailColumns[name] {
+			continue
+		}
+		if !covered[name] {
+			return false, true
+		}
+		needed = true
+	}
+
+	return true, needed
+}
+
+// useSiteDetails reports whether the list call should fetch the details of the whole site, i.e. whether
+// the query selects columns needing the detail of a row and all of them are in covered
+func useSiteDetails(d *plugin.QueryData, covered map[string]bool) bool {
+	covers, needed := siteDetailsCover(d, covered)
+	return covers && needed
+}
+
+// forEachSite calls fn for each site matching the site_name and site_type quals of the query and not excluded
+// by exclude_sites, processing up to max_site_concurrency sites at a time. Errors of a site ignored by the
+// connection config, such as not found errors, only skip that site. Other failures are logged per site and
+// returned once all the sites were processed, unless skip_failed_sites is set.
+func forEachSite(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, client *api.Client, fn func(context.Context, model.Site) error) error {
+	config := GetConfig(d.Connection)
+
+	// Default max site concurrency to 5 if not specified
+	concurrency := api.DefaultSiteConcurrency
+	if config.MaxSiteConcurrency != nil {
+		concurrency = *config.MaxSiteConcurrency
+	}
+	if concurrency <= 0 {
+		return fmt.Errorf("invalid max_site_concurrency: %d. Must be greater than 0", concurrency)
+	}
+
+	sites, err := listTargetSites(ctx, d, client, config)
+	if err != nil {
+		return err
+	}
+
+	shouldIgnore := shouldIgnoreErrors([]int{http.StatusNotFound})
+	err = api.ForEachSite(ctx, sites, concurrency, func(ctx context.Context, site model.Site) error {
+		// Skip the remaining sites once the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil
+		}
+
+		if err := fn(ctx, site); err != nil && !shouldIgnore(ctx, d, h, err) {
+			return err
+		}
+		return nil
+	})
+
+	return reportSiteErrors(ctx, config, err)
+}
+
+// reportSiteErrors logs the failure of each site of a *api.SiteErrors returned by api.ForEachSite and
+// returns it, so that the query fails with the name of every failed site. With skip_failed_sites, the
+// failed sites are only logged and the rows of the other sites are returned as a partial result.
+// Other errors are returned as is.
+func reportSiteErrors(ctx context.Context, config BigFixConfig, err error) error {
+	var siteErrors *api.SiteErrors
+	if !errors.As(err, &siteErrors) {
+		return err
+	}
+
+	for _, siteErr := range siteErrors.Errors {
+		plugin.Logger(ctx).Warn("bigfix.forEachSite", "site_name", siteErr.SiteName, "site_type", siteErr.SiteType, "api_err", siteErr.Err)
+	}
+
+	if config.SkipFailedSites != nil && *config.SkipFailedSites {
+		return nil
+	}
+	return err
+}
+
+// listTargetSites returns the sites matching the site_name and site_type quals of the query,
+// except those whose name matches one of the exclude_sites patterns
+func listTargetSites(ctx context.Context, d *plugin.QueryData, client *api.Client, config BigFixConfig) ([]model.Site, error) {
+	for _, pattern := range config.ExcludeSites {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid exclude_sites pattern %q: %w", pattern, err)
+		}
+	}
+
+	targetSiteName := d.EqualsQualString("site_name")
+	targetSiteType := d.EqualsQualString("site_type")
+
+	var sites []model.Site
+	err := client.Site.ListFunc(ctx, func(site model.Site) bool {
+		if targetSiteName != "" && targetSiteName != site.Name {
+			return true
+		}
+		if targetSiteType != "" && targetSiteType != site.Type {
+			return true
+		}
+		if isExcludedSite(config, site) {
+			plugin.Logger(ctx).Debug("bigfix.listTargetSites", "excluded_site", site.Name)
+			return true
+		}
+
+		sites = append(sites, site)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sites, nil
+}
+
+// isExcludedSite reports whether the site name matches one of the exclude_sites patterns, case-insensitively
+func isExcludedSite(config BigFixConfig, site model.Site) bool {
+	for _, pattern := range config.ExcludeSites {
+		if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(site.Name)); ok {
+			return true
+		}
+	}
+	return false
+}
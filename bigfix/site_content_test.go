@@ -0,0 +1,77 @@
+package bigfix
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-bigfix/api"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+func TestUseSiteDetails(t *testing.T) {
+	table := &plugin.Table{
+		Columns: []*plugin.Column{
+			{Name: "id"},
+			{Name: "name"},
+			{Name: "title", Hydrate: getBigFixFixlet},
+			{Name: "category", Hydrate: getBigFixFixlet},
+			{Name: "relevance", Hydrate: getBigFixFixlet},
+			{Name: "actions", Hydrate: getBigFixFixlet},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		columns []string
+		limit   *int64
+		want    bool
+	}{
+		{name: "list columns only", columns: []string{"id", "name"}, want: false},
+		{name: "covered detail columns", columns: []string{"id", "title", "category"}, want: true},
+		{name: "covered detail columns with small limit", columns: []string{"title"}, limit: ptr(int64(5)), want: true},
+		{name: "relevance", columns: []string{"title", "relevance"}, want: false},
+		{name: "uncovered detail column", columns: []string{"title", "actions"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &plugin.QueryData{
+				Table:        table,
+				QueryContext: &plugin.QueryContext{Columns: tt.columns, Limit: tt.limit},
+			}
+			if got := useSiteDetails(d, fixletSiteDetailColumns); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReportSiteErrors(t *testing.T) {
+	siteErr := &api.SiteError{SiteName: "BES Support", SiteType: "external", Err: errors.New("boom")}
+	someFailed := &api.SiteErrors{Errors: []*api.SiteError{siteErr}, Total: 3}
+	allFailed := &api.SiteErrors{Errors: []*api.SiteError{siteErr}, Total: 1}
+	other := errors.New("invalid max_site_concurrency")
+
+	tests := []struct {
+		name   string
+		config BigFixConfig
+		err    error
+		want   error
+	}{
+		{name: "no error", err: nil, want: nil},
+		{name: "some sites failed", err: someFailed, want: someFailed},
+		{name: "all sites failed", err: allFailed, want: allFailed},
+		{name: "some sites failed and skipped", config: BigFixConfig{SkipFailedSites: ptr(true)}, err: someFailed, want: nil},
+		{name: "skip disabled", config: BigFixConfig{SkipFailedSites: ptr(false)}, err: someFailed, want: someFailed},
+		{name: "other error", err: other, want: other},
+		{name: "other error with skip", config: BigFixConfig{SkipFailedSites: ptr(true)}, err: other, want: other},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := reportSiteErrors(testContext(), tt.config, tt.err); err != tt.want {
+				t.Errorf("got error %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
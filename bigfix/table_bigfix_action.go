@@ -2,7 +2,7 @@ package bigfix
 
 import (
 	"context"
-	"strings"
+	"net/http"
 
 	"github.com/turbot/steampipe-plugin-bigfix/api/model"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
@@ -23,14 +23,14 @@ func tableBigFixAction(ctx context.Context) *plugin.Table {
 			},
 			Hydrate: getBigFixAction,
 			IgnoreConfig: &plugin.IgnoreConfig{
-				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"not found"}),
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]int{http.StatusNotFound}),
 			},
 		},
 		HydrateConfig: []plugin.HydrateConfig{
 			{
 				Func: getBigFixAction,
 				IgnoreConfig: &plugin.IgnoreConfig{
-					ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"not found"}),
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors([]int{http.StatusNotFound}),
 				},
 			},
 		},
@@ -56,6 +56,12 @@ func tableBigFixAction(ctx context.Context) *plugin.Table {
 				Description: "The last modified timestamp of the action.",
 				Type:        proto.ColumnType_STRING,
 			},
+			{
+				Name:        "action_type",
+				Description: "The kind of action: single, multiple (multiple action group) or baseline.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixAction,
+			},
 			{
 				Name:        "title",
 				Description: "The title of the action.",
@@ -98,17 +104,148 @@ func tableBigFixAction(ctx context.Context) *plugin.Table {
 				Type:        proto.ColumnType_JSON,
 				Hydrate:     getBigFixAction,
 			},
+			{
+				Name:        "target_all_computers",
+				Description: "Whether the action targets all computers.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getBigFixAction,
+				Transform:   transform.FromField("Target.AllComputers"),
+			},
+			{
+				Name:        "target_computer_ids",
+				Description: "The IDs of the computers the action targets.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getBigFixAction,
+				Transform:   transform.FromField("Target.ComputerIDs"),
+			},
+			{
+				Name:        "target_computer_names",
+				Description: "The names of the computers the action targets.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getBigFixAction,
+				Transform:   transform.FromField("Target.ComputerNames"),
+			},
+			{
+				Name:        "target_relevance",
+				Description: "The custom relevance expression that selects the computers the action targets.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixAction,
+				Transform:   transform.FromField("Target.CustomRelevance"),
+			},
+			{
+				Name:        "target_computer_groups",
+				Description: "The computer groups the action targets.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getBigFixAction,
+				Transform:   transform.FromField("Target.ComputerGroups"),
+			},
+			{
+				Name:        "start_offset",
+				Description: "The offset from the issue time at which the action becomes active.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixAction,
+				Transform:   transform.FromField("Settings.StartDateTimeLocalOffset", "Settings.StartDateTimeOffset"),
+			},
+			{
+				Name:        "start_date_time_local",
+				Description: "The fixed local time at which the action becomes active.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixAction,
+				Transform:   transform.FromField("Settings.StartDateTimeLocal"),
+			},
+			{
+				Name:        "end_offset",
+				Description: "The offset from the issue time at which the action expires.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixAction,
+				Transform:   transform.FromField("Settings.EndDateTimeLocalOffset", "Settings.EndDateTimeOffset"),
+			},
+			{
+				Name:        "end_date_time_local",
+				Description: "The fixed local time at which the action expires.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixAction,
+				Transform:   transform.FromField("Settings.EndDateTimeLocal"),
+			},
+			{
+				Name:        "time_range_start",
+				Description: "The start of the daily time window in which the action may run.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixAction,
+				Transform:   transform.FromField("Settings.TimeRange.StartTime"),
+			},
+			{
+				Name:        "time_range_end",
+				Description: "The end of the daily time window in which the action may run.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixAction,
+				Transform:   transform.FromField("Settings.TimeRange.EndTime"),
+			},
+			{
+				Name:        "day_of_week_constraint",
+				Description: "The days of the week on which the action may run.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getBigFixAction,
+				Transform:   transform.FromField("Settings.DayOfWeekConstraint"),
+			},
+			{
+				Name:        "reapply_limit",
+				Description: "The maximum number of times the action is reapplied, or null if there is no limit.",
+				Type:        proto.ColumnType_INT,
+				Hydrate:     getBigFixAction,
+				Transform:   transform.FromField("Settings.ReapplyLimit").Transform(actionReapplyLimitValue),
+			},
+			{
+				Name:        "reapply_interval",
+				Description: "The interval between reapplications of the action.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixAction,
+				Transform:   transform.FromField("Settings.ReapplyInterval"),
+			},
+			{
+				Name:        "retry_count",
+				Description: "The number of times the action is retried after a failure.",
+				Type:        proto.ColumnType_INT,
+				Hydrate:     getBigFixAction,
+				Transform:   transform.FromField("Settings.RetryCount"),
+			},
+			{
+				Name:        "retry_wait",
+				Description: "The wait between retries of the action.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixAction,
+				Transform:   transform.FromField("Settings.RetryWait.Value"),
+			},
+			{
+				Name:        "temporal_distribution",
+				Description: "The period over which the action is spread across targeted computers.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixAction,
+				Transform:   transform.FromField("Settings.TemporalDistribution"),
+			},
 			{
 				Name:        "is_urgent",
 				Description: "Whether the action is marked as urgent.",
 				Type:        proto.ColumnType_BOOL,
 				Hydrate:     getBigFixAction,
 			},
+			{
+				Name:        "member_actions",
+				Description: "The member actions of a multiple action group or the components of a baseline action.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getBigFixAction,
+			},
 		},
 	}
 }
 
 func listBigFixActions(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// When used as a parent hydrate by child tables, skip the list call if the action is already known
+	if idQual := d.EqualsQuals["action_id"]; idQual != nil {
+		d.StreamListItem(ctx, model.Action{ID: int(idQual.GetInt64Value())})
+		return nil, nil
+	}
+
 	// Create the service
 	client, err := NewService(ctx, d)
 	if err != nil {
@@ -116,25 +253,20 @@ func listBigFixActions(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 		return nil, err
 	}
 
-	// Get all actions
-	actions, err := client.Action.List(ctx)
-	if err != nil {
-		// In the case of parent hydrate the Ignore config is not being honored.
-		if strings.Contains(strings.ToLower(err.Error()), "not found") {
-			return nil, nil
-		}
-		plugin.Logger(ctx).Error("bigfix_action.listBigFixActions", "api_err", err)
-		return nil, err
-	}
-
-	// Stream the actions
-	for _, action := range actions {
+	// Get all actions, streaming each one as it is decoded
+	err = client.Action.ListFunc(ctx, func(action model.Action) bool {
 		d.StreamListItem(ctx, action)
 
 		// Context can be cancelled due to manual cancellation or the limit has been hit
-		if d.RowsRemaining(ctx) == 0 {
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		// In the case of parent hydrate the Ignore config is not being honored, apply it here.
+		if shouldIgnoreErrors([]int{http.StatusNotFound})(ctx, d, h, err) {
 			return nil, nil
 		}
+		plugin.Logger(ctx).Error("bigfix_action.listBigFixActions", "api_err", err)
+		return nil, err
 	}
 
 	return nil, nil
@@ -143,10 +275,12 @@ func listBigFixActions(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 func getBigFixAction(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	// Get the action from the hydrate data
 	var actionID int
+	var lastModified string
 
 	if h.Item != nil {
 		action := h.Item.(model.Action)
 		actionID = action.ID
+		lastModified = action.LastModified
 	}
 
 	if idQual := d.EqualsQuals["id"]; idQual != nil {
@@ -165,7 +299,7 @@ func getBigFixAction(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrate
 	}
 
 	// Get the action detail
-	action, err := client.Action.Get(ctx, actionID)
+	action, err := client.Action.GetCached(ctx, actionID, lastModified)
 	if err != nil {
 		plugin.Logger(ctx).Error("bigfix_action.getBigFixAction", "api_error", err)
 		return nil, err
@@ -173,3 +307,14 @@ func getBigFixAction(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrate
 
 	return action, nil
 }
+
+//// TRANSFORM FUNCTIONS
+
+// actionReapplyLimitValue returns null for the reapply limit of an action without one
+func actionReapplyLimitValue(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	action, ok := d.HydrateItem.(*model.Action)
+	if !ok || action.Settings == nil || !action.Settings.HasReapplyLimit {
+		return nil, nil
+	}
+	return d.Value, nil
+}
@@ -0,0 +1,171 @@
+package bigfix
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/turbot/steampipe-plugin-bigfix/api/model"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// actionMemberRow represents a single member action of a multiple action group or baseline action
+type actionMemberRow struct {
+	ActionID                int
+	ActionTitle             string
+	ActionType              string
+	MemberIndex             int
+	GroupName               string
+	Title                   string
+	Relevance               string
+	ActionScript            string
+	SuccessCriteria         string
+	IncludeInGroupRelevance bool
+	SourceSiteName          string
+	SourceSiteURL           string
+	SourceFixletID          int
+	SourceFixletAction      string
+}
+
+func tableBigFixActionMember(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "bigfix_action_member",
+		Description: "BigFix Action Member contains the member actions of multiple action groups and the components of baseline actions.",
+		List: &plugin.ListConfig{
+			ParentHydrate: listBigFixActions,
+			Hydrate:       listBigFixActionMembers,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "action_id", Require: plugin.Optional},
+			},
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "action_id",
+				Description: "The ID of the parent action.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("ActionID"),
+			},
+			{
+				Name:        "action_title",
+				Description: "The title of the parent action.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "action_type",
+				Description: "The kind of the parent action: multiple or baseline.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "member_index",
+				Description: "The position of the member within the parent action, starting at 0.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "group_name",
+				Description: "The name of the baseline component group containing the member.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "title",
+				Description: "The title of the member action.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "relevance",
+				Description: "The relevance expression of the member action.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "action_script",
+				Description: "The action script of the member action.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "success_criteria",
+				Description: "The success criteria of the member action.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "include_in_group_relevance",
+				Description: "Whether the relevance of the member is included in the relevance of the parent action.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "source_site_name",
+				Description: "The name of the site containing the source fixlet.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "source_site_url",
+				Description: "The URL of the site containing the source fixlet.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SourceSiteURL"),
+			},
+			{
+				Name:        "source_fixlet_id",
+				Description: "The ID of the fixlet the member action was taken from.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("SourceFixletID"),
+			},
+			{
+				Name:        "source_fixlet_action",
+				Description: "The name of the fixlet action the member action was taken from.",
+				Type:        proto.ColumnType_STRING,
+			},
+		},
+	}
+}
+
+func listBigFixActionMembers(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Get the action from the parent hydrate
+	action := h.Item.(model.Action)
+
+	// Create the service
+	client, err := NewService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("bigfix_action_member.listBigFixActionMembers", "service_creation_error", err)
+		return nil, err
+	}
+
+	// Get the action detail, which carries the member actions
+	detail, err := client.Action.GetCached(ctx, action.ID, action.LastModified)
+	if err != nil {
+		// In the case of parent hydrate the Ignore config is not being honored, apply it here.
+		if shouldIgnoreErrors([]int{http.StatusNotFound})(ctx, d, h, err) {
+			return nil, nil
+		}
+		plugin.Logger(ctx).Error("bigfix_action_member.listBigFixActionMembers", "api_err", err)
+		return nil, err
+	}
+
+	// Stream one row per member action
+	for _, member := range detail.MemberActions {
+		row := actionMemberRow{
+			ActionID:                action.ID,
+			ActionTitle:             detail.Title,
+			ActionType:              detail.ActionType,
+			MemberIndex:             member.Index,
+			GroupName:               member.GroupName,
+			Title:                   member.Title,
+			Relevance:               member.Relevance,
+			ActionScript:            member.ActionScript,
+			SuccessCriteria:         member.SuccessCriteria,
+			IncludeInGroupRelevance: member.IncludeInGroupRelevance,
+			SourceSiteURL:           member.SourceSiteURL,
+		}
+		if member.SourceFixlet != nil {
+			row.SourceSiteName = member.SourceFixlet.SiteName
+			row.SourceFixletID = member.SourceFixlet.FixletID
+			row.SourceFixletAction = member.SourceFixlet.Action
+		}
+		d.StreamListItem(ctx, row)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,166 @@
+package bigfix
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/turbot/steampipe-plugin-bigfix/api/model"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableBigFixActionStatus(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "bigfix_action_status",
+		Description: "BigFix Action Status contains the per-computer execution results of actions, including status, state, retry counts, timings and exit codes.",
+		List: &plugin.ListConfig{
+			ParentHydrate: listBigFixActions,
+			Hydrate:       listBigFixActionStatuses,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "action_id", Require: plugin.Optional},
+			},
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "action_id",
+				Description: "The ID of the action.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("ActionID"),
+			},
+			{
+				Name:        "action_status",
+				Description: "The overall status of the action, e.g. Open, Stopped or Expired.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "date_issued",
+				Description: "The time the action was issued.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "computer_id",
+				Description: "The ID of the computer the result applies to, null for the action-level row of an action without computer results.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("ComputerID").Transform(actionComputerValue),
+			},
+			{
+				Name:        "computer_name",
+				Description: "The name of the computer the result applies to.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ComputerName").Transform(actionComputerValue),
+			},
+			{
+				Name:        "status",
+				Description: "The status message of the action on the computer.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Status").Transform(actionComputerValue),
+			},
+			{
+				Name:        "state",
+				Description: "The state of the action on the computer, e.g. Fixed, Failed or Not Relevant.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("State").Transform(actionComputerValue),
+			},
+			{
+				Name:        "is_error",
+				Description: "Whether the state of the action on the computer is an error state.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromField("IsError").Transform(actionComputerValue),
+			},
+			{
+				Name:        "apply_count",
+				Description: "The number of times the action has been applied on the computer.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("ApplyCount").Transform(actionComputerValue),
+			},
+			{
+				Name:        "retry_count",
+				Description: "The number of times the action has been retried on the computer.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("RetryCount").Transform(actionComputerValue),
+			},
+			{
+				Name:        "line_number",
+				Description: "The action script line number the computer last executed.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("LineNumber").Transform(actionComputerValue),
+			},
+			{
+				Name:        "start_time",
+				Description: "The time the action started on the computer.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("StartTime").Transform(actionComputerValue),
+			},
+			{
+				Name:        "end_time",
+				Description: "The time the action completed on the computer.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("EndTime").Transform(actionComputerValue),
+			},
+			{
+				Name:        "exit_code",
+				Description: "The exit code reported by the computer, if any.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("ExitCode").Transform(actionComputerValue),
+			},
+			{
+				Name:        "resource",
+				Description: "The resource URL of the action status.",
+				Type:        proto.ColumnType_STRING,
+			},
+		},
+	}
+}
+
+func listBigFixActionStatuses(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Get the action from the parent hydrate
+	action := h.Item.(model.Action)
+
+	// If the optional key qual is provided, only fetch if it matches the current action
+	if idQual := d.EqualsQuals["action_id"]; idQual != nil && int(idQual.GetInt64Value()) != action.ID {
+		return nil, nil
+	}
+
+	// Create the service
+	client, err := NewService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("bigfix_action_status.listBigFixActionStatuses", "service_creation_error", err)
+		return nil, err
+	}
+
+	// Get the per-computer status for this action
+	statuses, err := client.Action.Status(ctx, action.ID)
+	if err != nil {
+		// In the case of parent hydrate the Ignore config is not being honored, apply it here.
+		if shouldIgnoreErrors([]int{http.StatusNotFound})(ctx, d, h, err) {
+			return nil, nil
+		}
+		plugin.Logger(ctx).Error("bigfix_action_status.listBigFixActionStatuses", "api_err", err)
+		return nil, err
+	}
+
+	// Stream the statuses
+	for _, status := range statuses {
+		d.StreamListItem(ctx, status)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+// actionComputerValue returns null for the computer columns of the action-level row
+// of an action which no computer reported a result for yet
+func actionComputerValue(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	status, ok := d.HydrateItem.(model.ActionComputerStatus)
+	if !ok || !status.HasComputer() {
+		return nil, nil
+	}
+	return d.Value, nil
+}
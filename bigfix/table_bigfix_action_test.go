@@ -0,0 +1,36 @@
+package bigfix
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-bigfix/api/model"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func TestActionReapplyLimitValue(t *testing.T) {
+	tests := []struct {
+		name   string
+		action *model.Action
+		want   interface{}
+	}{
+		{name: "limit", action: &model.Action{Settings: &model.ActionSettings{HasReapplyLimit: true, ReapplyLimit: 3}}, want: 3},
+		{name: "no limit", action: &model.Action{Settings: &model.ActionSettings{ReapplyLimit: 0}}, want: nil},
+		{name: "no settings", action: &model.Action{}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &transform.TransformData{HydrateItem: tt.action}
+			if tt.action.Settings != nil {
+				d.Value = tt.action.Settings.ReapplyLimit
+			}
+			got, err := actionReapplyLimitValue(testContext(), d)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
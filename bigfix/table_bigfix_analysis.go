@@ -2,12 +2,13 @@ package bigfix
 
 import (
 	"context"
-	"strings"
+	"net/http"
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
 
+	"github.com/turbot/steampipe-plugin-bigfix/api"
 	"github.com/turbot/steampipe-plugin-bigfix/api/model"
 )
 
@@ -16,8 +17,7 @@ func tableBigFixAnalysis(ctx context.Context) *plugin.Table {
 		Name:        "bigfix_analysis",
 		Description: "BigFix Analysis contains custom queries and reporting tools with relevance expressions for endpoint assessment and compliance monitoring.",
 		List: &plugin.ListConfig{
-			ParentHydrate: listBigFixSites,
-			Hydrate:       listBigFixAnalyses,
+			Hydrate: listBigFixAnalyses,
 			KeyColumns: []*plugin.KeyColumn{
 				{Name: "site_name", Require: plugin.Optional},
 				{Name: "site_type", Require: plugin.Optional},
@@ -31,14 +31,14 @@ func tableBigFixAnalysis(ctx context.Context) *plugin.Table {
 			},
 			Hydrate: getBigFixAnalysis,
 			IgnoreConfig: &plugin.IgnoreConfig{
-				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"not found"}),
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]int{http.StatusNotFound}),
 			},
 		},
 		HydrateConfig: []plugin.HydrateConfig{
 			{
 				Func: getBigFixAnalysis,
 				IgnoreConfig: &plugin.IgnoreConfig{
-					ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"not found"}),
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors([]int{http.StatusNotFound}),
 				},
 			},
 		},
@@ -132,55 +132,60 @@ func tableBigFixAnalysis(ctx context.Context) *plugin.Table {
 	}
 }
 
+// analysisSiteDetailColumns are the columns populated by AnalysisService.ListDetails
+var analysisSiteDetailColumns = map[string]bool{
+	"title":               true,
+	"category":            true,
+	"source_release_date": true,
+}
+
 func listBigFixAnalyses(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Create the service
 	client, err := NewService(ctx, d)
 	if err != nil {
 		plugin.Logger(ctx).Error("bigfix_analysis.listBigFixAnalyses", "service_creation_error", err)
 		return nil, err
 	}
 
-	// Get site from parent hydrate
-	site := h.Item.(model.Site)
-
-	// Check if optional key quals are provided to filter the results
-	var targetSiteName, targetSiteType string
-	if nameQual := d.EqualsQuals["site_name"]; nameQual != nil {
-		targetSiteName = nameQual.GetStringValue()
-	}
-	if typeQual := d.EqualsQuals["site_type"]; typeQual != nil {
-		targetSiteType = typeQual.GetStringValue()
-	}
-
-	// If optional quals are provided, only fetch if they match the current site
-	if targetSiteName != "" && targetSiteName != site.Name {
-		return nil, nil
-	}
-	if targetSiteType != "" && targetSiteType != site.Type {
-		return nil, nil
-	}
-
-	// Fetch analyses for the site
-	analyses, err := client.Analysis.List(ctx, site.Name, site.Type)
+	// List the analyses of the sites in parallel, up to max_site_concurrency sites at a time
+	err = forEachSite(ctx, d, h, client, func(ctx context.Context, site model.Site) error {
+		return listSiteAnalyses(ctx, d, client, site)
+	})
 	if err != nil {
-		// In the case of parent hydrate the Ignore config is not being honored.
-		if strings.Contains(strings.ToLower(err.Error()), "not found") {
-			return nil, nil
-		}
 		plugin.Logger(ctx).Error("bigfix_analysis.listBigFixAnalyses", "api_err", err)
 		return nil, err
 	}
 
-	// Return each analysis as a separate row
-	for _, analysis := range analyses {
-		d.StreamListItem(ctx, analysis)
+	return nil, nil
+}
 
-		// Context can be cancelled due to manual cancellation or the limit has been hit
-		if d.RowsRemaining(ctx) == 0 {
-			return nil, nil
+// listSiteAnalyses streams the analyses of a site
+func listSiteAnalyses(ctx context.Context, d *plugin.QueryData, client *api.Client, site model.Site) error {
+	// Fetch the selected details of all the analyses of the site at once instead of getting each one
+	var details map[int]model.Analysis
+	if useSiteDetails(d, analysisSiteDetailColumns) {
+		var err error
+		details, err = client.Analysis.ListDetails(ctx, site.Name, site.Type)
+		if err != nil {
+			// The details are only an optimisation, each analysis is then got by its hydrate call instead
+			plugin.Logger(ctx).Warn("bigfix_analysis.listSiteAnalyses", "site_name", site.Name, "details_api_err", err)
+			details = nil
 		}
 	}
 
-	return nil, nil
+	// Fetch analyses for the site, streaming each one as it is decoded
+	return client.Analysis.ListFunc(ctx, site.Name, site.Type, func(analysis model.Analysis) bool {
+		if detail, ok := details[analysis.ID]; ok {
+			analysis.Title = detail.Title
+			analysis.Category = detail.Category
+			analysis.SourceReleaseDate = detail.SourceReleaseDate
+			analysis.DetailsFetched = true
+		}
+		d.StreamListItem(ctx, analysis)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
 }
 
 func getBigFixAnalysis(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
@@ -190,14 +195,21 @@ func getBigFixAnalysis(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 		return nil, err
 	}
 
-	var siteName, siteType string
+	var siteName, siteType, lastModified string
 	var analysisID int
 
 	if h.Item != nil {
-		site := h.Item.(model.Analysis)
-		siteName = site.SiteName
-		siteType = site.SiteType
-		analysisID = site.ID
+		analysis := h.Item.(model.Analysis)
+
+		// The selected details were already fetched for the whole site by the list call
+		if analysis.DetailsFetched {
+			return &analysis, nil
+		}
+
+		siteName = analysis.SiteName
+		siteType = analysis.SiteType
+		analysisID = analysis.ID
+		lastModified = analysis.LastModified
 	}
 
 	if nameQual := d.EqualsQuals["site_name"]; nameQual != nil {
@@ -215,7 +227,7 @@ func getBigFixAnalysis(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 	}
 
 	// Fetch the specific analysis
-	analysis, err := client.Analysis.Get(ctx, siteName, siteType, analysisID)
+	analysis, err := client.Analysis.GetCached(ctx, siteName, siteType, analysisID, lastModified)
 	if err != nil {
 		plugin.Logger(ctx).Error("bigfix_analysis.getBigFixAnalysis", "api_err", err)
 		return nil, err
@@ -0,0 +1,270 @@
+package bigfix
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-bigfix/api/model"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableBigFixBaseline(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "bigfix_baseline",
+		Description: "BigFix Baseline contains collections of fixlets and tasks that are deployed together as a single action, with their component groups and source content references.",
+		List: &plugin.ListConfig{
+			ParentHydrate: listBigFixSites,
+			Hydrate:       listBigFixBaselines,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "site_name", Require: plugin.Optional},
+				{Name: "site_type", Require: plugin.Optional},
+			},
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "site_name", Require: plugin.Required},
+				{Name: "site_type", Require: plugin.Required},
+				{Name: "id", Require: plugin.Required},
+			},
+			Hydrate: getBigFixBaseline,
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]int{http.StatusNotFound}),
+			},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getBigFixBaseline,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors([]int{http.StatusNotFound}),
+				},
+			},
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "id",
+				Description: "The ID of the baseline.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "name",
+				Description: "The name of the baseline.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "site_name",
+				Description: "The name of the site containing the baseline.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "site_type",
+				Description: "The type of the site containing the baseline.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource",
+				Description: "The resource URL of the baseline.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "last_modified",
+				Description: "The last modified timestamp of the baseline.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "title",
+				Description: "The title of the baseline.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixBaseline,
+			},
+			{
+				Name:        "description",
+				Description: "The description of the baseline.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixBaseline,
+			},
+			{
+				Name:        "relevance",
+				Description: "The relevance expressions of the baseline.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getBigFixBaseline,
+			},
+			{
+				Name:        "category",
+				Description: "The category of the baseline.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixBaseline,
+			},
+			{
+				Name:        "source",
+				Description: "The source of the baseline.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixBaseline,
+			},
+			{
+				Name:        "source_id",
+				Description: "The source ID of the baseline.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixBaseline,
+			},
+			{
+				Name:        "source_release_date",
+				Description: "The source release date of the baseline.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixBaseline,
+			},
+			{
+				Name:        "source_severity",
+				Description: "The source severity of the baseline.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixBaseline,
+			},
+			{
+				Name:        "cve_names",
+				Description: "The CVE names associated with the baseline.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixBaseline,
+			},
+			{
+				Name:        "mime_fields",
+				Description: "MIME fields of the baseline.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getBigFixBaseline,
+			},
+			{
+				Name:        "domain",
+				Description: "The domain of the baseline.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixBaseline,
+			},
+			{
+				Name:        "components",
+				Description: "The components of the baseline, including their group, source site, source fixlet ID, action name and whether their action is still the same as in the source fixlet.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getBigFixBaseline,
+			},
+		},
+	}
+}
+
+func listBigFixBaselines(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Get the site from the parent hydrate
+	site := h.Item.(model.Site)
+
+	// Check if optional key quals are provided to filter the results
+	var targetSiteName, targetSiteType string
+	if nameQual := d.EqualsQuals["site_name"]; nameQual != nil {
+		targetSiteName = nameQual.GetStringValue()
+	}
+	if typeQual := d.EqualsQuals["site_type"]; typeQual != nil {
+		targetSiteType = typeQual.GetStringValue()
+	}
+
+	// If optional quals are provided, only fetch if they match the current site
+	if targetSiteName != "" && targetSiteName != site.Name {
+		return nil, nil
+	}
+	if targetSiteType != "" && targetSiteType != site.Type {
+		return nil, nil
+	}
+
+	// Create the service
+	client, err := NewService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("bigfix_baseline.listBigFixBaselines", "service_creation_error", err)
+		return nil, err
+	}
+
+	// Get the baselines for this site, streaming each one as it is decoded
+	err = client.Baseline.ListFunc(ctx, site.Name, site.Type, func(baseline model.Baseline) bool {
+		d.StreamListItem(ctx, baseline)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		// In the case of parent hydrate the Ignore config is not being honored, apply it here.
+		if shouldIgnoreErrors([]int{http.StatusNotFound})(ctx, d, h, err) {
+			return nil, nil
+		}
+		plugin.Logger(ctx).Error("bigfix_baseline.listBigFixBaselines", "api_err", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func getBigFixBaseline(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Get the baseline from the hydrate data
+	var siteName, siteType string
+	var baselineID int
+
+	if h.Item != nil {
+		baseline := h.Item.(model.Baseline)
+		siteName = baseline.SiteName
+		siteType = baseline.SiteType
+		baselineID = baseline.ID
+	}
+
+	if nameQual := d.EqualsQuals["site_name"]; nameQual != nil {
+		siteName = nameQual.GetStringValue()
+	}
+	if typeQual := d.EqualsQuals["site_type"]; typeQual != nil {
+		siteType = typeQual.GetStringValue()
+	}
+	if idQual := d.EqualsQuals["id"]; idQual != nil {
+		baselineID = int(idQual.GetInt64Value())
+	}
+
+	if siteName == "" || siteType == "" || baselineID == 0 {
+		return nil, nil
+	}
+
+	// Create the service
+	client, err := NewService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("bigfix_baseline.getBigFixBaseline", "service_creation_error", err)
+		return nil, err
+	}
+
+	// Get the baseline detail
+	baseline, err := client.Baseline.Get(ctx, siteName, siteType, baselineID)
+	if err != nil {
+		plugin.Logger(ctx).Error("bigfix_baseline.getBigFixBaseline", "api_error", err)
+		return nil, err
+	}
+
+	// Comparing the components with their source content costs a request per source, only do it when needed
+	if slices.Contains(d.QueryContext.Columns, "components") && len(baseline.Components) > 0 {
+		sites, err := getBaselineSourceSites(ctx, d, h)
+		if err != nil {
+			plugin.Logger(ctx).Error("bigfix_baseline.getBigFixBaseline", "sync_error", err)
+			return nil, err
+		}
+		client.Baseline.SyncComponents(ctx, baseline, sites.(map[string]model.Site))
+	}
+
+	return baseline, nil
+}
+
+// baselineSourceSitesTTL is how long the sites listed to sync baseline components are reused, so that they
+// are listed once for all the baselines of a query rather than once per baseline
+const baselineSourceSitesTTL = 5 * time.Minute
+
+// getBaselineSourceSites returns the sites of the connection keyed by gather URL, memoized in the connection cache
+var getBaselineSourceSites = plugin.HydrateFunc(getBaselineSourceSitesUncached).Memoize(func(config *plugin.MemoizeConfiguration) {
+	config.Ttl = baselineSourceSitesTTL
+})
+
+func getBaselineSourceSitesUncached(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	client, err := NewService(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Baseline.SourceSites(ctx)
+}
@@ -2,27 +2,43 @@ package bigfix
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/turbot/steampipe-plugin-bigfix/api"
 	"github.com/turbot/steampipe-plugin-bigfix/api/model"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/quals"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
 )
 
 //// TABLE DEFINITION
 
-func tableBigFixComputer(_ context.Context) *plugin.Table {
-	return &plugin.Table{
+func tableBigFixComputer(ctx context.Context, td *plugin.TableMapData) (*plugin.Table, error) {
+	table := &plugin.Table{
 		Name:        "bigfix_computer",
 		Description: "BigFix Computer contains endpoint inventory data including system specifications, network information, OS details, hardware configuration, and reporting status for managed computers.",
 		List: &plugin.ListConfig{
 			Hydrate: listBigFixComputers,
+			// Translated into a session relevance condition, see computerRelevanceCondition
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "name", Require: plugin.Optional, Operators: []string{"=", "~~"}},
+				{Name: "os", Require: plugin.Optional, Operators: []string{"=", "~~"}},
+				{Name: "ip_address", Require: plugin.Optional, Operators: []string{"=", "~~"}},
+				{Name: "last_report_time", Require: plugin.Optional, Operators: []string{"=", "<", "<=", ">", ">="}},
+			},
 		},
 		Get: &plugin.GetConfig{
 			KeyColumns: plugin.SingleColumn("id"),
 			Hydrate:    getBigFixComputer,
 			IgnoreConfig: &plugin.IgnoreConfig{
-				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"not found"}),
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]int{http.StatusNotFound}),
 			},
 		},
 		Columns: []*plugin.Column{
@@ -236,6 +252,15 @@ func tableBigFixComputer(_ context.Context) *plugin.Table {
 			},
 		},
 	}
+
+	// Add a column for each retrieved property of the BigFix server
+	propertyColumns, err := computerPropertyColumns(ctx, td, table.Columns)
+	if err != nil {
+		return nil, err
+	}
+	table.Columns = append(table.Columns, propertyColumns...)
+
+	return table, nil
 }
 
 func listBigFixComputers(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
@@ -245,19 +270,25 @@ func listBigFixComputers(ctx context.Context, d *plugin.QueryData, h *plugin.Hyd
 		return nil, err
 	}
 
-	computers, err := client.Computer.List()
-	if err != nil {
-		plugin.Logger(ctx).Error("bigfix_computer.listBigFixComputers", "api_err", err)
-		return nil, err
-	}
+	// Request the retrieved properties backing the selected columns in the list call
+	propertyNames, _ := computerListProperties(d)
 
-	for _, computer := range computers {
+	streamComputer := func(computer model.Computer) bool {
 		d.StreamListItem(ctx, computer)
 
 		// Context can be cancelled due to manual cancellation or the limit has been hit
-		if d.RowsRemaining(ctx) == 0 {
-			return nil, nil
-		}
+		return d.RowsRemaining(ctx) != 0
+	}
+
+	// Let the server filter the computers when the quals can be expressed in relevance
+	if condition := computerRelevanceCondition(ctx, d); condition != "" {
+		err = client.Computer.ListWhereFunc(ctx, condition, propertyNames, streamComputer)
+	} else {
+		err = client.Computer.ListWithPropertiesFunc(ctx, propertyNames, streamComputer)
+	}
+	if err != nil {
+		plugin.Logger(ctx).Error("bigfix_computer.listBigFixComputers", "api_err", err)
+		return nil, err
 	}
 
 	return nil, nil
@@ -270,6 +301,15 @@ func getBigFixComputer(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 		return nil, err
 	}
 
+	// The list call already returned everything the selected columns need
+	if h != nil && h.Item != nil {
+		if computer, ok := h.Item.(model.Computer); ok {
+			if _, complete := computerListProperties(d); complete {
+				return &computer, nil
+			}
+		}
+	}
+
 	// Try to get id from quals; when hydrating from list, use h.Item
 	var id int64
 	if qual := d.EqualsQuals["id"]; qual != nil {
@@ -293,3 +333,465 @@ func getBigFixComputer(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 
 	return computer, nil
 }
+
+//// LIST FIELDS
+
+// computerListColumns are the columns returned by default when listing computers
+var computerListColumns = map[string]bool{
+	"id":               true,
+	"name":             true,
+	"os":               true,
+	"cpu":              true,
+	"ip_address":       true,
+	"last_report_time": true,
+}
+
+// computerColumnProperties maps the columns parsed from a retrieved property to the name of that property
+var computerColumnProperties = map[string]string{
+	"ipv6_address":          "IPv6 Address",
+	"dns_name":              "DNS Name",
+	"mac_address":           "MAC Address",
+	"os_family":             "OS Family",
+	"os_name":               "OS Name",
+	"os_version":            "OS Version",
+	"user_name":             "User Name",
+	"ram":                   "RAM",
+	"locked":                "Locked",
+	"bes_relay_selection":   "BES Relay Selection Method",
+	"relay":                 "Relay",
+	"distance_to_bes_relay": "Distance to BES Relay",
+	"agent_type":            "Agent Type",
+	"device_type":           "Device Type",
+	"agent_version":         "Agent Version",
+	"computer_type":         "Computer Type",
+	"license_type":          "License Type",
+	"free_space_on_system":  "Free Space on System Drive",
+	"total_size_of_system":  "Total Size of System Drive",
+	"bios":                  "BIOS",
+	"subnet_address":        "Subnet Address",
+	"client_settings":       "Client Settings",
+	"subscribed_sites":      "Subscribed Sites",
+}
+
+// computerListProperties returns the retrieved properties to request when listing computers for the columns
+// selected by the query. complete is false when a selected column, such as properties, can only be populated
+// by getting each computer.
+func computerListProperties(d *plugin.QueryData) (propertyNames []string, complete bool) {
+	columns := map[string]*plugin.Column{}
+	for _, column := range d.Table.Columns {
+		columns[column.Name] = column
+	}
+
+	complete = true
+	requested := map[string]bool{}
+	for _, name := range d.QueryContext.Columns {
+		column, ok := columns[name]
+		// Skip the columns returned by default and those not defined by the table, e.g. _ctx
+		if !ok || computerListColumns[name] {
+			continue
+		}
+
+		propertyName, ok := computerColumnProperties[name]
+		if !ok {
+			propertyName, ok = computerPropertyColumnName(column)
+		}
+		if !ok || !api.IsListableProperty(propertyName) {
+			complete = false
+			continue
+		}
+
+		if !requested[propertyName] {
+			requested[propertyName] = true
+			propertyNames = append(propertyNames, propertyName)
+		}
+	}
+
+	return propertyNames, complete
+}
+
+// computerPropertyColumnName returns the name of the retrieved property of a column added by computerPropertyColumns
+func computerPropertyColumnName(column *plugin.Column) (string, bool) {
+	if column.Transform == nil || len(column.Transform.Transforms) == 0 {
+		return "", false
+	}
+	param, ok := column.Transform.Transforms[0].Param.(computerPropertyColumn)
+	return param.PropertyName, ok
+}
+
+//// RELEVANCE FILTERING
+
+// computerQualInspectors maps the key columns of the list call to the relevance inspector of their value
+var computerQualInspectors = map[string]string{
+	"name":             "name of it",
+	"os":               "operating system of it",
+	"ip_address":       api.ComputerIPAddressRelevance,
+	"last_report_time": "last report time of it",
+}
+
+// computerRelevanceCondition returns the session relevance condition equivalent to the quals of the query,
+// or an empty string if none of them can be translated. Postgres filters the returned rows again,
+// so quals which cannot be translated, such as like patterns with a _ wildcard, are simply left out.
+func computerRelevanceCondition(ctx context.Context, d *plugin.QueryData) string {
+	var conditions []string
+	for _, column := range []string{"name", "os", "ip_address", "last_report_time"} {
+		keyColumnQuals := d.Quals[column]
+		if keyColumnQuals == nil {
+			continue
+		}
+
+		for _, q := range keyColumnQuals.Quals {
+			condition, ok := computerQualRelevance(column, q)
+			if !ok {
+				plugin.Logger(ctx).Debug("bigfix_computer.computerRelevanceCondition", "skipped_qual", column, "operator", q.Operator)
+				continue
+			}
+			conditions = append(conditions, condition)
+		}
+	}
+
+	return strings.Join(conditions, " and ")
+}
+
+// computerQualRelevance translates a single qual into a relevance condition
+func computerQualRelevance(column string, q *quals.Qual) (string, bool) {
+	inspector := computerQualInspectors[column]
+
+	if column == "last_report_time" {
+		ts := q.Value.GetTimestampValue()
+		if ts == nil {
+			return "", false
+		}
+		value := api.RelevanceString(ts.AsTime().UTC().Format(time.RFC1123Z)) + " as time"
+		return fmt.Sprintf("(%s) %s %s", inspector, q.Operator, value), true
+	}
+
+	// String values, list values such as IN clauses are not translated
+	if _, isString := q.Value.GetValue().(*proto.QualValue_StringValue); !isString {
+		return "", false
+	}
+	value := q.Value.GetStringValue()
+
+	var condition string
+	switch q.Operator {
+	case quals.QualOperatorEqual:
+		condition = "it = " + api.RelevanceString(value)
+	case quals.QualOperatorLike:
+		c, ok := likeRelevance(value)
+		if !ok {
+			return "", false
+		}
+		condition = c
+	default:
+		return "", false
+	}
+
+	return fmt.Sprintf("exists (%s) whose (%s)", inspector, condition), true
+}
+
+// likeRelevance translates a like pattern into a relevance condition on it. Only patterns with % wildcards
+// at the start or the end can be translated, e.g. 'Win%' to `it starts with "Win"`.
+func likeRelevance(pattern string) (string, bool) {
+	if strings.ContainsAny(pattern, "_\\") {
+		return "", false
+	}
+
+	prefix := strings.HasSuffix(pattern, "%")
+	suffix := strings.HasPrefix(pattern, "%")
+	value := strings.TrimSuffix(strings.TrimPrefix(pattern, "%"), "%")
+	if strings.Contains(value, "%") {
+		return "", false
+	}
+
+	literal := api.RelevanceString(value)
+	switch {
+	case value == "" && (prefix || suffix):
+		return "true", true
+	case prefix && suffix:
+		return "it contains " + literal, true
+	case prefix:
+		return "it starts with " + literal, true
+	case suffix:
+		return "it ends with " + literal, true
+	default:
+		return "it = " + literal, true
+	}
+}
+
+//// DYNAMIC COLUMNS
+
+// computerPropertyColumnTypes maps the types accepted by computer_property_types to column types
+var computerPropertyColumnTypes = map[string]proto.ColumnType{
+	"string":    proto.ColumnType_STRING,
+	"int":       proto.ColumnType_INT,
+	"double":    proto.ColumnType_DOUBLE,
+	"bool":      proto.ColumnType_BOOL,
+	"timestamp": proto.ColumnType_TIMESTAMP,
+	"json":      proto.ColumnType_JSON,
+}
+
+// computerPropertyColumn is the transform param of a retrieved property column
+type computerPropertyColumn struct {
+	PropertyName string
+	Type         proto.ColumnType
+}
+
+// computerPropertySampleSize is the number of computers whose values are used to infer the column types of properties
+const computerPropertySampleSize = 100
+
+// computerPropertyColumns returns a column for each retrieved property listed by the BigFix server.
+// Without computer_properties, columns are added for all custom properties, otherwise for all properties
+// matching one of its patterns. Column types are set in computer_property_types, or inferred from the values
+// the first computers report. If the properties cannot be listed, e.g. because the server is unreachable,
+// string columns are added for the plain names of computer_properties.
+func computerPropertyColumns(ctx context.Context, td *plugin.TableMapData, existingColumns []*plugin.Column) ([]*plugin.Column, error) {
+	config := GetConfig(td.Connection)
+
+	for _, pattern := range config.ComputerProperties {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid computer_properties pattern %q: %w", pattern, err)
+		}
+	}
+
+	columnTypes := map[string]proto.ColumnType{}
+	for name, typeName := range config.ComputerPropertyTypes {
+		columnType, ok := computerPropertyColumnTypes[typeName]
+		if !ok {
+			return nil, fmt.Errorf("invalid computer_property_types type %q for %s. Must be one of: string, int, double, bool, timestamp, json", typeName, name)
+		}
+		columnTypes[strings.ToLower(name)] = columnType
+	}
+
+	client, err := connectionClient(ctx, td.Connection, td.ConnectionCache)
+	var properties []model.BigFixProperty
+	if err == nil {
+		properties, err = client.Property.List(ctx)
+	}
+	listed := err == nil
+	if !listed {
+		plugin.Logger(ctx).Warn("bigfix_computer.computerPropertyColumns", "api_error", err, "fallback", "computer_properties names")
+		properties = configuredComputerProperties(config)
+	}
+
+	columnNames := map[string]bool{}
+	for _, column := range existingColumns {
+		columnNames[column.Name] = true
+	}
+
+	var selected []model.BigFixProperty
+	for _, property := range properties {
+		if !includeComputerProperty(config, property) {
+			continue
+		}
+
+		name := propertyColumnName(property.Name)
+		if name == "" || columnNames[name] {
+			plugin.Logger(ctx).Debug("bigfix_computer.computerPropertyColumns", "skipped_property", property.Name, "column", name)
+			continue
+		}
+		columnNames[name] = true
+		selected = append(selected, property)
+	}
+
+	// Infer the types not set in the config from the values of the properties
+	if listed {
+		var untyped []string
+		for _, property := range selected {
+			if _, ok := columnTypes[strings.ToLower(property.Name)]; !ok && api.IsListableProperty(property.Name) {
+				untyped = append(untyped, property.Name)
+			}
+		}
+		inferred, err := inferComputerPropertyTypes(ctx, client, untyped)
+		if err != nil {
+			plugin.Logger(ctx).Warn("bigfix_computer.computerPropertyColumns", "api_error", err, "fallback", "string columns")
+		}
+		for name, columnType := range inferred {
+			columnTypes[strings.ToLower(name)] = columnType
+		}
+	}
+
+	columns := make([]*plugin.Column, 0, len(selected))
+	for _, property := range selected {
+		columnType, ok := columnTypes[strings.ToLower(property.Name)]
+		if !ok {
+			columnType = proto.ColumnType_STRING
+		}
+
+		columns = append(columns, &plugin.Column{
+			Name:        propertyColumnName(property.Name),
+			Description: fmt.Sprintf("The value of the %q retrieved property.", property.Name),
+			Type:        columnType,
+			Hydrate:     getBigFixComputer,
+			Transform:   transform.FromP(computerPropertyValue, computerPropertyColumn{PropertyName: property.Name, Type: columnType}),
+		})
+	}
+
+	return columns, nil
+}
+
+// configuredComputerProperties returns the properties named in computer_properties, skipping glob patterns
+// as they cannot be resolved without listing the properties of the server
+func configuredComputerProperties(config BigFixConfig) []model.BigFixProperty {
+	var properties []model.BigFixProperty
+	for _, pattern := range config.ComputerProperties {
+		if !strings.ContainsAny(pattern, `*?[\`) {
+			properties = append(properties, model.BigFixProperty{Name: pattern})
+		}
+	}
+	return properties
+}
+
+// inferComputerPropertyTypes returns the column type of each property from its values on the first computers
+// listed by the server: int, double, bool or timestamp when all the values have that type, json when computers
+// report several values, and string otherwise. Properties without any value are left out.
+func inferComputerPropertyTypes(ctx context.Context, client *api.Client, propertyNames []string) (map[string]proto.ColumnType, error) {
+	if len(propertyNames) == 0 {
+		return nil, nil
+	}
+
+	values := map[string][]string{}
+	multiValued := map[string]bool{}
+	sampled := 0
+	err := client.Computer.ListWithPropertiesFunc(ctx, propertyNames, func(computer model.Computer) bool {
+		for _, name := range propertyNames {
+			propertyValues := computer.PropertyValues(name)
+			if len(propertyValues) > 1 {
+				multiValued[name] = true
+			}
+			values[name] = append(values[name], propertyValues...)
+		}
+
+		sampled++
+		return sampled < computerPropertySampleSize
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	types := map[string]proto.ColumnType{}
+	for _, name := range propertyNames {
+		if multiValued[name] {
+			types[name] = proto.ColumnType_JSON
+		} else if columnType, ok := inferColumnType(values[name]); ok {
+			types[name] = columnType
+		}
+	}
+	return types, nil
+}
+
+// inferredColumnTypes are the column types inferred from property values, from the most specific one
+var inferredColumnTypes = []struct {
+	columnType proto.ColumnType
+	matches    func(string) bool
+}{
+	{proto.ColumnType_INT, func(v string) bool { _, err := strconv.ParseInt(v, 10, 64); return err == nil }},
+	{proto.ColumnType_DOUBLE, func(v string) bool { _, err := strconv.ParseFloat(v, 64); return err == nil }},
+	{proto.ColumnType_BOOL, func(v string) bool { return strings.EqualFold(v, "true") || strings.EqualFold(v, "false") }},
+	{proto.ColumnType_TIMESTAMP, func(v string) bool { return model.ParseTime(v) != nil }},
+}
+
+// inferColumnType returns the most specific column type all the values can be converted to by
+// computerPropertyValue, false if there are no values
+func inferColumnType(values []string) (proto.ColumnType, bool) {
+	var nonEmpty []string
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			nonEmpty = append(nonEmpty, value)
+		}
+	}
+	if len(nonEmpty) == 0 {
+		return proto.ColumnType_UNKNOWN, false
+	}
+
+	for _, inferred := range inferredColumnTypes {
+		if slices.ContainsFunc(nonEmpty, func(v string) bool { return !inferred.matches(v) }) {
+			continue
+		}
+		return inferred.columnType, true
+	}
+	return proto.ColumnType_STRING, true
+}
+
+// includeComputerProperty reports whether a column is added for the property
+func includeComputerProperty(config BigFixConfig, property model.BigFixProperty) bool {
+	if len(config.ComputerProperties) == 0 {
+		// Reserved properties are mostly covered by the columns above
+		return property.IsReserved == 0
+	}
+
+	for _, pattern := range config.ComputerProperties {
+		if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(property.Name)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// propertyColumnName converts a property name such as "Serial Number (BIOS)" to a column name such as serial_number_bios
+func propertyColumnName(propertyName string) string {
+	var b strings.Builder
+	separator := false
+	for _, r := range strings.ToLower(propertyName) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if separator && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			separator = false
+			b.WriteRune(r)
+		} else {
+			separator = true
+		}
+	}
+
+	name := b.String()
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
+
+//// TRANSFORM FUNCTIONS
+
+// computerPropertyValue returns the value of a retrieved property converted to the type of its column.
+// Values that cannot be converted, such as relevance evaluation errors, are returned as null.
+func computerPropertyValue(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	computer, ok := d.HydrateItem.(*model.Computer)
+	if !ok || computer == nil {
+		return nil, nil
+	}
+
+	column := d.Param.(computerPropertyColumn)
+	values := computer.PropertyValues(column.PropertyName)
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	value := strings.TrimSpace(values[0])
+	switch column.Type {
+	case proto.ColumnType_JSON:
+		return values, nil
+	case proto.ColumnType_INT:
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return i, nil
+		}
+	case proto.ColumnType_DOUBLE:
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f, nil
+		}
+	case proto.ColumnType_BOOL:
+		switch strings.ToLower(value) {
+		case "true", "yes", "1":
+			return true, nil
+		case "false", "no", "0":
+			return false, nil
+		}
+	case proto.ColumnType_TIMESTAMP:
+		if t := model.ParseTime(value); t != nil {
+			return *t, nil
+		}
+	default:
+		// Properties reporting several values are joined, use the json type to get them as an array
+		return strings.Join(values, "\n"), nil
+	}
+
+	return nil, nil
+}
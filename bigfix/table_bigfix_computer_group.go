@@ -0,0 +1,204 @@
+package bigfix
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/turbot/steampipe-plugin-bigfix/api/model"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableBigFixComputerGroup(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "bigfix_computer_group",
+		Description: "BigFix Computer Group contains the manual and automatic groupings of endpoints used for targeting, with their membership criteria.",
+		List: &plugin.ListConfig{
+			ParentHydrate: listBigFixSites,
+			Hydrate:       listBigFixComputerGroups,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "site_name", Require: plugin.Optional},
+				{Name: "site_type", Require: plugin.Optional},
+			},
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "site_name", Require: plugin.Required},
+				{Name: "site_type", Require: plugin.Required},
+				{Name: "id", Require: plugin.Required},
+			},
+			Hydrate: getBigFixComputerGroup,
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]int{http.StatusNotFound}),
+			},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getBigFixComputerGroup,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors([]int{http.StatusNotFound}),
+				},
+			},
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "id",
+				Description: "The ID of the computer group.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "name",
+				Description: "The name of the computer group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "site_name",
+				Description: "The name of the site containing the computer group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "site_type",
+				Description: "The type of the site containing the computer group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource",
+				Description: "The resource URL of the computer group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "last_modified",
+				Description: "The last modified timestamp of the computer group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "title",
+				Description: "The title of the computer group.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixComputerGroup,
+			},
+			{
+				Name:        "type",
+				Description: "The type of the computer group (automatic, manual).",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixComputerGroup,
+			},
+			{
+				Name:        "domain",
+				Description: "The domain of the computer group.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixComputerGroup,
+			},
+			{
+				Name:        "evaluate_on_client",
+				Description: "Whether group membership is evaluated on the client rather than on the server.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getBigFixComputerGroup,
+			},
+			{
+				Name:        "join_by_intersection",
+				Description: "Whether computers must match all criteria (true) or any criterion (false) to be members.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getBigFixComputerGroup,
+			},
+			{
+				Name:        "criteria",
+				Description: "The membership criteria of the computer group, including property, relevance and group conditions.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getBigFixComputerGroup,
+			},
+		},
+	}
+}
+
+func listBigFixComputerGroups(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Get the site from the parent hydrate
+	site := h.Item.(model.Site)
+
+	// Check if optional key quals are provided to filter the results
+	var targetSiteName, targetSiteType string
+	if nameQual := d.EqualsQuals["site_name"]; nameQual != nil {
+		targetSiteName = nameQual.GetStringValue()
+	}
+	if typeQual := d.EqualsQuals["site_type"]; typeQual != nil {
+		targetSiteType = typeQual.GetStringValue()
+	}
+
+	// If optional quals are provided, only fetch if they match the current site
+	if targetSiteName != "" && targetSiteName != site.Name {
+		return nil, nil
+	}
+	if targetSiteType != "" && targetSiteType != site.Type {
+		return nil, nil
+	}
+
+	// Create the service
+	client, err := NewService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("bigfix_computer_group.listBigFixComputerGroups", "service_creation_error", err)
+		return nil, err
+	}
+
+	// Get the computer groups for this site, streaming each one as it is decoded
+	err = client.ComputerGroup.ListFunc(ctx, site.Name, site.Type, func(group model.ComputerGroup) bool {
+		d.StreamListItem(ctx, group)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		// In the case of parent hydrate the Ignore config is not being honored, apply it here.
+		if shouldIgnoreErrors([]int{http.StatusNotFound})(ctx, d, h, err) {
+			return nil, nil
+		}
+		plugin.Logger(ctx).Error("bigfix_computer_group.listBigFixComputerGroups", "api_err", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func getBigFixComputerGroup(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Get the computer group from the hydrate data
+	var siteName, siteType string
+	var groupID int
+
+	if h.Item != nil {
+		group := h.Item.(model.ComputerGroup)
+		siteName = group.SiteName
+		siteType = group.SiteType
+		groupID = group.ID
+	}
+
+	if nameQual := d.EqualsQuals["site_name"]; nameQual != nil {
+		siteName = nameQual.GetStringValue()
+	}
+	if typeQual := d.EqualsQuals["site_type"]; typeQual != nil {
+		siteType = typeQual.GetStringValue()
+	}
+	if idQual := d.EqualsQuals["id"]; idQual != nil {
+		groupID = int(idQual.GetInt64Value())
+	}
+
+	if siteName == "" || siteType == "" || groupID == 0 {
+		return nil, nil
+	}
+
+	// Create the service
+	client, err := NewService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("bigfix_computer_group.getBigFixComputerGroup", "service_creation_error", err)
+		return nil, err
+	}
+
+	// Get the computer group detail
+	group, err := client.ComputerGroup.Get(ctx, siteName, siteType, groupID)
+	if err != nil {
+		plugin.Logger(ctx).Error("bigfix_computer_group.getBigFixComputerGroup", "api_error", err)
+		return nil, err
+	}
+
+	return group, nil
+}
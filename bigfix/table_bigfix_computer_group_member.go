@@ -0,0 +1,140 @@
+package bigfix
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/turbot/steampipe-plugin-bigfix/api/model"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableBigFixComputerGroupMember(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "bigfix_computer_group_member",
+		Description: "BigFix Computer Group Member maps computer groups to the computers that are currently members of them.",
+		List: &plugin.ListConfig{
+			ParentHydrate: listBigFixSites,
+			Hydrate:       listBigFixComputerGroupMembers,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "site_name", Require: plugin.Optional},
+				{Name: "site_type", Require: plugin.Optional},
+				{Name: "group_id", Require: plugin.Optional},
+			},
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "group_id",
+				Description: "The ID of the computer group.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("GroupID"),
+			},
+			{
+				Name:        "group_name",
+				Description: "The name of the computer group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "computer_id",
+				Description: "The ID of the member computer.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.FromField("ComputerID"),
+			},
+			{
+				Name:        "site_name",
+				Description: "The name of the site containing the computer group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "site_type",
+				Description: "The type of the site containing the computer group.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "last_report_time",
+				Description: "The last time the member computer reported to the BigFix server.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "resource",
+				Description: "The resource URL of the member computer.",
+				Type:        proto.ColumnType_STRING,
+			},
+		},
+	}
+}
+
+func listBigFixComputerGroupMembers(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Get the site from the parent hydrate
+	site := h.Item.(model.Site)
+
+	// Check if optional key quals are provided to filter the results
+	var targetSiteName, targetSiteType string
+	var targetGroupID int
+	if nameQual := d.EqualsQuals["site_name"]; nameQual != nil {
+		targetSiteName = nameQual.GetStringValue()
+	}
+	if typeQual := d.EqualsQuals["site_type"]; typeQual != nil {
+		targetSiteType = typeQual.GetStringValue()
+	}
+	if idQual := d.EqualsQuals["group_id"]; idQual != nil {
+		targetGroupID = int(idQual.GetInt64Value())
+	}
+
+	// If optional quals are provided, only fetch if they match the current site
+	if targetSiteName != "" && targetSiteName != site.Name {
+		return nil, nil
+	}
+	if targetSiteType != "" && targetSiteType != site.Type {
+		return nil, nil
+	}
+
+	// Create the service
+	client, err := NewService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("bigfix_computer_group_member.listBigFixComputerGroupMembers", "service_creation_error", err)
+		return nil, err
+	}
+
+	// Get the computer groups for this site
+	groups, err := client.ComputerGroup.List(ctx, site.Name, site.Type)
+	if err != nil {
+		// In the case of parent hydrate the Ignore config is not being honored, apply it here.
+		if shouldIgnoreErrors([]int{http.StatusNotFound})(ctx, d, h, err) {
+			return nil, nil
+		}
+		plugin.Logger(ctx).Error("bigfix_computer_group_member.listBigFixComputerGroupMembers", "api_err", err)
+		return nil, err
+	}
+
+	for _, group := range groups {
+		if targetGroupID != 0 && targetGroupID != group.ID {
+			continue
+		}
+
+		// Get the members of this group, streaming each one as it is decoded
+		limitReached := false
+		err := client.ComputerGroup.ListMembersFunc(ctx, site.Name, site.Type, group.ID, func(member model.ComputerGroupMember) bool {
+			member.GroupName = group.Name
+			d.StreamListItem(ctx, member)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			limitReached = d.RowsRemaining(ctx) == 0
+			return !limitReached
+		})
+		if err != nil {
+			if shouldIgnoreErrors([]int{http.StatusNotFound})(ctx, d, h, err) {
+				continue
+			}
+			plugin.Logger(ctx).Error("bigfix_computer_group_member.listBigFixComputerGroupMembers", "api_err", err)
+			return nil, err
+		}
+
+		if limitReached {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
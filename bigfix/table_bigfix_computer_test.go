@@ -0,0 +1,259 @@
+package bigfix
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/quals"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestInferColumnType(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		want   proto.ColumnType
+		wantOK bool
+	}{
+		{name: "no values", values: nil, wantOK: false},
+		{name: "empty values", values: []string{"", " "}, wantOK: false},
+		{name: "int", values: []string{"42", "", "-7"}, want: proto.ColumnType_INT, wantOK: true},
+		{name: "double", values: []string{"42", "3.5"}, want: proto.ColumnType_DOUBLE, wantOK: true},
+		{name: "bool", values: []string{"True", "False"}, want: proto.ColumnType_BOOL, wantOK: true},
+		{name: "timestamp", values: []string{"Tue, 15 Oct 2024 10:00:00 +0000", "Wed, 2 Oct 2024 08:30:00 -0500"}, want: proto.ColumnType_TIMESTAMP, wantOK: true},
+		{name: "mixed", values: []string{"42", "n/a"}, want: proto.ColumnType_STRING, wantOK: true},
+		{name: "string", values: []string{"Windows 11"}, want: proto.ColumnType_STRING, wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := inferColumnType(tt.values)
+			if ok != tt.wantOK || (ok && got != tt.want) {
+				t.Errorf("got %v %v, want %v %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+// testServerConnection returns a connection to a TLS test server serving handler
+func testServerConnection(t *testing.T, name string, handler http.Handler, config BigFixConfig) *plugin.Connection {
+	t.Helper()
+
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(serverURL.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config.ServerName = ptr(serverURL.Hostname())
+	config.Port = ptr(port)
+	config.InsecureSkipVerify = ptr(true)
+	config.MaxRetries = ptr(1)
+	return testConnection(name, config)
+}
+
+func TestComputerPropertyColumns(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/properties", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<BESAPI>
+			<Property Resource="r"><Name>Computer Name</Name><ID>1</ID><IsReserved>1</IsReserved></Property>
+			<Property Resource="r"><Name>Free Space on System Drive</Name><ID>2</ID><IsReserved>0</IsReserved></Property>
+			<Property Resource="r"><Name>Install Date</Name><ID>3</ID><IsReserved>0</IsReserved></Property>
+			<Property Resource="r"><Name>Installed Applications</Name><ID>4</ID><IsReserved>0</IsReserved></Property>
+			<Property Resource="r"><Name>Asset Tag</Name><ID>5</ID><IsReserved>0</IsReserved></Property>
+			<Property Resource="r"><Name>Never Reported</Name><ID>6</ID><IsReserved>0</IsReserved></Property>
+		</BESAPI>`))
+	})
+	mux.HandleFunc("/api/computers", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<BESAPI>
+			<Computer Resource="r"><ID>1</ID>
+				<Property Name="Free Space on System Drive">1024</Property>
+				<Property Name="Install Date">Tue, 15 Oct 2024 10:00:00 +0000</Property>
+				<Property Name="Installed Applications">a</Property>
+				<Property Name="Installed Applications">b</Property>
+				<Property Name="Asset Tag">0042</Property>
+			</Computer>
+			<Computer Resource="r"><ID>2</ID>
+				<Property Name="Free Space on System Drive">2048</Property>
+				<Property Name="Install Date">Wed, 2 Oct 2024 08:30:00 -0500</Property>
+				<Property Name="Asset Tag">0043</Property>
+			</Computer>
+		</BESAPI>`))
+	})
+
+	conn := testServerConnection(t, "bigfix_properties", mux, BigFixConfig{
+		ComputerPropertyTypes: map[string]string{"Asset Tag": "string"},
+	})
+
+	columns, err := computerPropertyColumns(testContext(), &plugin.TableMapData{Connection: conn, ConnectionCache: testConnectionCache(t, conn.Name)}, nil)
+	if err != nil {
+		t.Fatalf("property columns: %v", err)
+	}
+
+	want := map[string]proto.ColumnType{
+		"free_space_on_system_drive": proto.ColumnType_INT,
+		"install_date":               proto.ColumnType_TIMESTAMP,
+		"installed_applications":     proto.ColumnType_JSON,
+		"asset_tag":                  proto.ColumnType_STRING,
+		"never_reported":             proto.ColumnType_STRING,
+	}
+	if len(columns) != len(want) {
+		t.Errorf("got %d columns, want %d", len(columns), len(want))
+	}
+	for _, column := range columns {
+		if columnType, ok := want[column.Name]; !ok || column.Type != columnType {
+			t.Errorf("got column %s of type %v, want %v", column.Name, column.Type, want[column.Name])
+		}
+	}
+}
+
+func TestComputerPropertyColumnsFallback(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	conn := testServerConnection(t, "bigfix_properties_fallback", handler, BigFixConfig{
+		ComputerProperties:    []string{"Serial Number", "BES Relay*", "Free Space on System Drive"},
+		ComputerPropertyTypes: map[string]string{"Free Space on System Drive": "int"},
+	})
+
+	columns, err := computerPropertyColumns(testContext(), &plugin.TableMapData{Connection: conn, ConnectionCache: testConnectionCache(t, conn.Name)}, nil)
+	if err != nil {
+		t.Fatalf("property columns: %v", err)
+	}
+
+	// Patterns cannot be resolved without the properties of the server, plain names still get columns
+	want := map[string]proto.ColumnType{
+		"serial_number":              proto.ColumnType_STRING,
+		"free_space_on_system_drive": proto.ColumnType_INT,
+	}
+	if len(columns) != len(want) {
+		t.Errorf("got %d columns, want %d", len(columns), len(want))
+	}
+	for _, column := range columns {
+		if columnType, ok := want[column.Name]; !ok || column.Type != columnType {
+			t.Errorf("got column %s of type %v, want %v", column.Name, column.Type, want[column.Name])
+		}
+	}
+}
+
+func TestLikeRelevance(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+		wantOK  bool
+	}{
+		{pattern: "Win%", want: `it starts with "Win"`, wantOK: true},
+		{pattern: "%2019", want: `it ends with "2019"`, wantOK: true},
+		{pattern: "%Server%", want: `it contains "Server"`, wantOK: true},
+		{pattern: "host-1", want: `it = "host-1"`, wantOK: true},
+		{pattern: "%", want: "true", wantOK: true},
+		{pattern: "%%", want: "true", wantOK: true},
+		{pattern: `say "hi"%`, want: `it starts with "say %22hi%22"`, wantOK: true},
+		{pattern: "Win%2019", wantOK: false},
+		{pattern: "host_1", wantOK: false},
+		{pattern: `100\%`, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			got, ok := likeRelevance(tt.pattern)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("got %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestComputerQualRelevance(t *testing.T) {
+	stringValue := func(s string) *proto.QualValue {
+		return &proto.QualValue{Value: &proto.QualValue_StringValue{StringValue: s}}
+	}
+	reportTime := time.Date(2024, 10, 15, 10, 20, 30, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		column string
+		qual   *quals.Qual
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "equal",
+			column: "name",
+			qual:   &quals.Qual{Operator: quals.QualOperatorEqual, Value: stringValue("host-1")},
+			want:   `exists (name of it) whose (it = "host-1")`,
+			wantOK: true,
+		},
+		{
+			name:   "equal with quotes and percent",
+			column: "name",
+			qual:   &quals.Qual{Operator: quals.QualOperatorEqual, Value: stringValue(`a "b" 100%`)},
+			want:   `exists (name of it) whose (it = "a %22b%22 100%25")`,
+			wantOK: true,
+		},
+		{
+			name:   "like",
+			column: "os",
+			qual:   &quals.Qual{Operator: quals.QualOperatorLike, Value: stringValue("Win%")},
+			want:   `exists (operating system of it) whose (it starts with "Win")`,
+			wantOK: true,
+		},
+		{
+			name:   "like with underscore",
+			column: "os",
+			qual:   &quals.Qual{Operator: quals.QualOperatorLike, Value: stringValue("Win_2019")},
+			wantOK: false,
+		},
+		{
+			name:   "not equal",
+			column: "name",
+			qual:   &quals.Qual{Operator: quals.QualOperatorNotEqual, Value: stringValue("host-1")},
+			wantOK: false,
+		},
+		{
+			name:   "in list",
+			column: "name",
+			qual: &quals.Qual{Operator: quals.QualOperatorEqual, Value: &proto.QualValue{Value: &proto.QualValue_ListValue{
+				ListValue: &proto.QualValueList{Values: []*proto.QualValue{stringValue("a"), stringValue("b")}},
+			}}},
+			wantOK: false,
+		},
+		{
+			name:   "timestamp",
+			column: "last_report_time",
+			qual: &quals.Qual{Operator: quals.QualOperatorGreater, Value: &proto.QualValue{Value: &proto.QualValue_TimestampValue{
+				TimestampValue: timestamppb.New(reportTime),
+			}}},
+			want:   `(last report time of it) > "Tue, 15 Oct 2024 10:20:30 +0000" as time`,
+			wantOK: true,
+		},
+		{
+			name:   "timestamp without value",
+			column: "last_report_time",
+			qual:   &quals.Qual{Operator: quals.QualOperatorLess, Value: stringValue("yesterday")},
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := computerQualRelevance(tt.column, tt.qual)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("got %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
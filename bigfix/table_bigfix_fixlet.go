@@ -2,8 +2,9 @@ package bigfix
 
 import (
 	"context"
-	"strings"
+	"net/http"
 
+	"github.com/turbot/steampipe-plugin-bigfix/api"
 	"github.com/turbot/steampipe-plugin-bigfix/api/model"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
@@ -15,8 +16,7 @@ func tableBigFixFixlet(ctx context.Context) *plugin.Table {
 		Name:        "bigfix_fixlet",
 		Description: "BigFix Fixlet contains security patches, software updates, and configuration changes with metadata, relevance expressions, and categories for deployment.",
 		List: &plugin.ListConfig{
-			ParentHydrate: listBigFixSites,
-			Hydrate:       listBigFixFixlets,
+			Hydrate: listBigFixFixlets,
 			KeyColumns: []*plugin.KeyColumn{
 				{Name: "site_name", Require: plugin.Optional},
 				{Name: "site_type", Require: plugin.Optional},
@@ -30,14 +30,14 @@ func tableBigFixFixlet(ctx context.Context) *plugin.Table {
 			},
 			Hydrate: getBigFixFixlet,
 			IgnoreConfig: &plugin.IgnoreConfig{
-				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"not found"}),
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]int{http.StatusNotFound}),
 			},
 		},
 		HydrateConfig: []plugin.HydrateConfig{
 			{
 				Func: getBigFixFixlet,
 				IgnoreConfig: &plugin.IgnoreConfig{
-					ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"not found"}),
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors([]int{http.StatusNotFound}),
 				},
 			},
 		},
@@ -161,27 +161,16 @@ func tableBigFixFixlet(ctx context.Context) *plugin.Table {
 	}
 }
 
-func listBigFixFixlets(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	// Get the site from the parent hydrate
-	site := h.Item.(model.Site)
-
-	// Check if optional key quals are provided to filter the results
-	var targetSiteName, targetSiteType string
-	if nameQual := d.EqualsQuals["site_name"]; nameQual != nil {
-		targetSiteName = nameQual.GetStringValue()
-	}
-	if typeQual := d.EqualsQuals["site_type"]; typeQual != nil {
-		targetSiteType = typeQual.GetStringValue()
-	}
-
-	// If optional quals are provided, only fetch if they match the current site
-	if targetSiteName != "" && targetSiteName != site.Name {
-		return nil, nil
-	}
-	if targetSiteType != "" && targetSiteType != site.Type {
-		return nil, nil
-	}
+// fixletSiteDetailColumns are the columns populated by FixletService.ListDetails
+var fixletSiteDetailColumns = map[string]bool{
+	"title":               true,
+	"category":            true,
+	"source_severity":     true,
+	"cve_names":           true,
+	"source_release_date": true,
+}
 
+func listBigFixFixlets(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	// Create the service
 	client, err := NewService(ctx, d)
 	if err != nil {
@@ -189,40 +178,66 @@ func listBigFixFixlets(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 		return nil, err
 	}
 
-	// Get the fixlets for this site
-	fixlets, err := client.Fixlet.List(ctx, site.Name, site.Type)
+	// List the fixlets of the sites in parallel, up to max_site_concurrency sites at a time
+	err = forEachSite(ctx, d, h, client, func(ctx context.Context, site model.Site) error {
+		return listSiteFixlets(ctx, d, client, site)
+	})
 	if err != nil {
-		// In the case of parent hydrate the Ignore config is not being honored.
-		if strings.Contains(strings.ToLower(err.Error()), "not found") {
-			return nil, nil
-		}
 		plugin.Logger(ctx).Error("bigfix_fixlet.listBigFixFixlets", "api_err", err)
 		return nil, err
 	}
 
-	// Stream the fixlets
-	for _, fixlet := range fixlets {
-		d.StreamListItem(ctx, fixlet)
+	return nil, nil
+}
 
-		// Context can be cancelled due to manual cancellation or the limit has been hit
-		if d.RowsRemaining(ctx) == 0 {
-			return nil, nil
+// listSiteFixlets streams the fixlets of a site
+func listSiteFixlets(ctx context.Context, d *plugin.QueryData, client *api.Client, site model.Site) error {
+	// Fetch the selected details of all the fixlets of the site at once instead of getting each one
+	var details map[int]model.Fixlet
+	if useSiteDetails(d, fixletSiteDetailColumns) {
+		var err error
+		details, err = client.Fixlet.ListDetails(ctx, site.Name, site.Type)
+		if err != nil {
+			// The details are only an optimisation, each fixlet is then got by its hydrate call instead
+			plugin.Logger(ctx).Warn("bigfix_fixlet.listSiteFixlets", "site_name", site.Name, "details_api_err", err)
+			details = nil
 		}
 	}
 
-	return nil, nil
+	// Get the fixlets for this site, streaming each one as it is decoded
+	return client.Fixlet.ListFunc(ctx, site.Name, site.Type, func(fixlet model.Fixlet) bool {
+		if detail, ok := details[fixlet.ID]; ok {
+			fixlet.Title = detail.Title
+			fixlet.Category = detail.Category
+			fixlet.SourceSeverity = detail.SourceSeverity
+			fixlet.CVENames = detail.CVENames
+			fixlet.SourceReleaseDate = detail.SourceReleaseDate
+			fixlet.DetailsFetched = true
+		}
+		d.StreamListItem(ctx, fixlet)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
 }
 
 func getBigFixFixlet(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	// Get the fixlet from the hydrate data
-	var siteName, siteType string
+	var siteName, siteType, lastModified string
 	var fixletID int
 
 	if h.Item != nil {
 		fixlet := h.Item.(model.Fixlet)
+
+		// The selected details were already fetched for the whole site by the list call
+		if fixlet.DetailsFetched {
+			return &fixlet, nil
+		}
+
 		siteName = fixlet.SiteName
 		siteType = fixlet.SiteType
 		fixletID = fixlet.ID
+		lastModified = fixlet.LastModified
 	}
 
 	if nameQual := d.EqualsQuals["site_name"]; nameQual != nil {
@@ -247,7 +262,7 @@ func getBigFixFixlet(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrate
 	}
 
 	// Get the fixlet detail
-	fixlet, err := client.Fixlet.Get(ctx, siteName, siteType, fixletID)
+	fixlet, err := client.Fixlet.GetCached(ctx, siteName, siteType, fixletID, lastModified)
 	if err != nil {
 		plugin.Logger(ctx).Error("bigfix_fixlet.getBigFixFixlet", "api_error", err)
 		return nil, err
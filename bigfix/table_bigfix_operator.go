@@ -0,0 +1,321 @@
+package bigfix
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/turbot/steampipe-plugin-bigfix/api/model"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableBigFixOperator(ctx context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "bigfix_operator",
+		Description: "BigFix Operator contains console and API users with their privileges, login permissions, LDAP identity and the roles, sites and computers assigned to them.",
+		List: &plugin.ListConfig{
+			Hydrate: listBigFixOperators,
+		},
+		Get: &plugin.GetConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "name", Require: plugin.Required},
+			},
+			Hydrate: getBigFixOperator,
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]int{http.StatusNotFound}),
+			},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getBigFixOperator,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors([]int{http.StatusNotFound}),
+				},
+			},
+			{
+				Func: getBigFixOperatorRoles,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors([]int{http.StatusNotFound}),
+				},
+			},
+			{
+				Func: getBigFixOperatorSites,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors([]int{http.StatusNotFound}),
+				},
+			},
+			{
+				Func: getBigFixOperatorComputers,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors([]int{http.StatusNotFound}),
+				},
+			},
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the operator.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "id",
+				Description: "The ID of the operator.",
+				Type:        proto.ColumnType_INT,
+				Hydrate:     getBigFixOperator,
+				Transform:   transform.FromField("ID"),
+			},
+			{
+				Name:        "resource",
+				Description: "The resource URL of the operator.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "last_login_time",
+				Description: "The last time the operator logged in.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "master_operator",
+				Description: "Whether the operator is a master operator.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getBigFixOperator,
+			},
+			{
+				Name:        "custom_content",
+				Description: "Whether the operator can create custom content.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getBigFixOperator,
+			},
+			{
+				Name:        "show_other_actions",
+				Description: "Whether the operator can see actions issued by other operators.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getBigFixOperator,
+			},
+			{
+				Name:        "stop_other_actions",
+				Description: "Whether the operator can stop actions issued by other operators.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getBigFixOperator,
+			},
+			{
+				Name:        "can_create_actions",
+				Description: "Whether the operator can create actions.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getBigFixOperator,
+			},
+			{
+				Name:        "post_action_behavior_privilege",
+				Description: "The post action behavior privilege of the operator.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixOperator,
+			},
+			{
+				Name:        "action_script_commands_privilege",
+				Description: "The action script commands privilege of the operator.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixOperator,
+			},
+			{
+				Name:        "can_send_multiple_refresh",
+				Description: "Whether the operator can send refresh commands to multiple computers.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getBigFixOperator,
+			},
+			{
+				Name:        "can_submit_queries",
+				Description: "Whether the operator can submit client queries.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getBigFixOperator,
+			},
+			{
+				Name:        "can_lock",
+				Description: "Whether the operator can lock computers.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getBigFixOperator,
+			},
+			{
+				Name:        "unmanaged_asset_privilege",
+				Description: "The unmanaged asset privilege of the operator.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixOperator,
+			},
+			{
+				Name:        "ldap_server_id",
+				Description: "The ID of the LDAP server the operator authenticates against.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixOperator,
+				Transform:   transform.FromField("LDAPServerID"),
+			},
+			{
+				Name:        "ldap_dn",
+				Description: "The LDAP distinguished name of the operator.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBigFixOperator,
+				Transform:   transform.FromField("LDAPDN"),
+			},
+			{
+				Name:        "interface_logins",
+				Description: "The interface login permissions of the operator.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getBigFixOperator,
+				Transform:   transform.FromField("InterfaceLogins"),
+			},
+			{
+				Name:        "roles",
+				Description: "The roles assigned to the operator.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getBigFixOperatorRoles,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "sites",
+				Description: "The sites the operator has access to, with the permission level on each site.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getBigFixOperatorSites,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "computers",
+				Description: "The computers the operator administers.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getBigFixOperatorComputers,
+				Transform:   transform.FromValue(),
+			},
+		},
+	}
+}
+
+func listBigFixOperators(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Create the service
+	client, err := NewService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("bigfix_operator.listBigFixOperators", "service_creation_error", err)
+		return nil, err
+	}
+
+	// Get all operators, streaming each one as it is decoded
+	err = client.Operator.ListFunc(ctx, func(operator model.Operator) bool {
+		d.StreamListItem(ctx, operator)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("bigfix_operator.listBigFixOperators", "api_err", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func getBigFixOperator(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	name := operatorName(d, h)
+	if name == "" {
+		return nil, nil
+	}
+
+	// Create the service
+	client, err := NewService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("bigfix_operator.getBigFixOperator", "service_creation_error", err)
+		return nil, err
+	}
+
+	// Get the operator detail
+	operator, err := client.Operator.Get(ctx, name)
+	if err != nil {
+		plugin.Logger(ctx).Error("bigfix_operator.getBigFixOperator", "api_error", err)
+		return nil, err
+	}
+
+	return operator, nil
+}
+
+func getBigFixOperatorRoles(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	name := operatorName(d, h)
+	if name == "" {
+		return nil, nil
+	}
+
+	// Create the service
+	client, err := NewService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("bigfix_operator.getBigFixOperatorRoles", "service_creation_error", err)
+		return nil, err
+	}
+
+	// Get the roles of the operator
+	roles, err := client.Operator.GetRoles(ctx, name)
+	if err != nil {
+		plugin.Logger(ctx).Error("bigfix_operator.getBigFixOperatorRoles", "api_error", err)
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+func getBigFixOperatorSites(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	name := operatorName(d, h)
+	if name == "" {
+		return nil, nil
+	}
+
+	// Create the service
+	client, err := NewService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("bigfix_operator.getBigFixOperatorSites", "service_creation_error", err)
+		return nil, err
+	}
+
+	// Get the sites of the operator
+	sites, err := client.Operator.GetSites(ctx, name)
+	if err != nil {
+		plugin.Logger(ctx).Error("bigfix_operator.getBigFixOperatorSites", "api_error", err)
+		return nil, err
+	}
+
+	return sites, nil
+}
+
+func getBigFixOperatorComputers(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	name := operatorName(d, h)
+	if name == "" {
+		return nil, nil
+	}
+
+	// Create the service
+	client, err := NewService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("bigfix_operator.getBigFixOperatorComputers", "service_creation_error", err)
+		return nil, err
+	}
+
+	// Get the computers administered by the operator
+	computers, err := client.Operator.GetComputers(ctx, name)
+	if err != nil {
+		plugin.Logger(ctx).Error("bigfix_operator.getBigFixOperatorComputers", "api_error", err)
+		return nil, err
+	}
+
+	return computers, nil
+}
+
+// operatorName returns the operator name from the quals or, when hydrating list items, from h.Item
+func operatorName(d *plugin.QueryData, h *plugin.HydrateData) string {
+	if h != nil && h.Item != nil {
+		switch operator := h.Item.(type) {
+		case model.Operator:
+			return operator.Name
+		case *model.Operator:
+			return operator.Name
+		}
+	}
+
+	if nameQual := d.EqualsQuals["name"]; nameQual != nil {
+		return nameQual.GetStringValue()
+	}
+
+	return ""
+}
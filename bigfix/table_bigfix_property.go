@@ -2,6 +2,7 @@ package bigfix
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/turbot/steampipe-plugin-bigfix/api/model"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
@@ -22,7 +23,7 @@ func tableBigFixProperty(ctx context.Context) *plugin.Table {
 			},
 			Hydrate: getBigFixProperty,
 			IgnoreConfig: &plugin.IgnoreConfig{
-				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"not found"}),
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]int{http.StatusNotFound}),
 			},
 		},
 		Columns: []*plugin.Column{
@@ -70,21 +71,16 @@ func listBigFixProperties(ctx context.Context, d *plugin.QueryData, h *plugin.Hy
 		return nil, err
 	}
 
-	// Get all properties
-	properties, err := client.Property.List(ctx)
-	if err != nil {
-		plugin.Logger(ctx).Error("bigfix_property.listBigFixProperties", "api_err", err)
-		return nil, err
-	}
-
-	// Stream the properties
-	for _, property := range properties {
+	// Get all properties, streaming each one as it is decoded
+	err = client.Property.ListFunc(ctx, func(property model.BigFixProperty) bool {
 		d.StreamListItem(ctx, property)
 
 		// Context can be cancelled due to manual cancellation or the limit has been hit
-		if d.RowsRemaining(ctx) == 0 {
-			return nil, nil
-		}
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("bigfix_property.listBigFixProperties", "api_err", err)
+		return nil, err
 	}
 
 	return nil, nil
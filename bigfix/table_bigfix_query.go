@@ -0,0 +1,142 @@
+package bigfix
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-bigfix/api/model"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// queryRow is a single answer of a session relevance query together with the
+// query level evaluation details
+type queryRow struct {
+	Relevance      string
+	TupleIndex     int
+	Answer         string
+	Type           string
+	Values         []model.QueryAnswer
+	Error          string
+	EvaluationTime string
+	Plurality      string
+	Resource       string
+}
+
+//// TABLE DEFINITION
+
+func tableBigFixQuery(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "bigfix_query",
+		Description: "BigFix Query evaluates an arbitrary session relevance expression on the BigFix server and returns one row per answer or tuple.",
+		List: &plugin.ListConfig{
+			Hydrate: listBigFixQuery,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "relevance", Require: plugin.Required, CacheMatch: "exact"},
+			},
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "relevance",
+				Description: "The session relevance expression to evaluate.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromQual("relevance"),
+			},
+			{
+				Name:        "tuple_index",
+				Description: "The position of the answer within the query result, starting at 0.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "answer",
+				Description: "The answer as text. Tuple members are joined with a comma.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "type",
+				Description: "The relevance type of the answer, e.g. string or ( string, integer ) for tuples.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "values",
+				Description: "The typed values of the answer. Single answers have one element, tuples one element per member.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "error",
+				Description: "The evaluation error returned by the server, if any.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "evaluation_time",
+				Description: "The time the server took to evaluate the expression.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "plurality",
+				Description: "Whether the expression evaluated to a singular or plural result.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource",
+				Description: "The resource URL of the query.",
+				Type:        proto.ColumnType_STRING,
+			},
+		},
+	}
+}
+
+func listBigFixQuery(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	var relevance string
+	if relevanceQual := d.EqualsQuals["relevance"]; relevanceQual != nil {
+		relevance = relevanceQual.GetStringValue()
+	}
+	if relevance == "" {
+		return nil, nil
+	}
+
+	client, err := NewService(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("bigfix_query.listBigFixQuery", "service_creation_error", err)
+		return nil, err
+	}
+
+	query, err := client.Query.Run(ctx, relevance)
+	if err != nil {
+		plugin.Logger(ctx).Error("bigfix_query.listBigFixQuery", "api_err", err)
+		return nil, err
+	}
+
+	// Surface evaluation errors as a row so they are visible in the results
+	if len(query.Results) == 0 && query.Error != "" {
+		d.StreamListItem(ctx, queryRow{
+			Relevance:      query.Relevance,
+			Error:          query.Error,
+			EvaluationTime: query.EvaluationTime,
+			Plurality:      query.Plurality,
+			Resource:       query.Resource,
+		})
+		return nil, nil
+	}
+
+	for _, result := range query.Results {
+		d.StreamListItem(ctx, queryRow{
+			Relevance:      query.Relevance,
+			TupleIndex:     result.TupleIndex,
+			Answer:         result.Answer,
+			Type:           result.Type,
+			Values:         result.Values,
+			Error:          query.Error,
+			EvaluationTime: query.EvaluationTime,
+			Plurality:      query.Plurality,
+			Resource:       query.Resource,
+		})
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
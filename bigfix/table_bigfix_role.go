@@ -2,6 +2,7 @@ package bigfix
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/turbot/steampipe-plugin-bigfix/api/model"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
@@ -22,14 +23,14 @@ func tableBigFixRole(ctx context.Context) *plugin.Table {
 			},
 			Hydrate: getBigFixRole,
 			IgnoreConfig: &plugin.IgnoreConfig{
-				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"not found"}),
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]int{http.StatusNotFound}),
 			},
 		},
 		HydrateConfig: []plugin.HydrateConfig{
 			{
 				Func: getBigFixRole,
 				IgnoreConfig: &plugin.IgnoreConfig{
-					ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"not found"}),
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors([]int{http.StatusNotFound}),
 				},
 			},
 		},
@@ -116,11 +117,42 @@ func tableBigFixRole(ctx context.Context) *plugin.Table {
 				Type:        proto.ColumnType_JSON,
 				Transform:   transform.FromField("InterfaceLogins"),
 			},
+			{
+				Name:        "operators",
+				Description: "The names of the operators explicitly assigned to the role.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getBigFixRole,
+			},
+			{
+				Name:        "ldap_assignments",
+				Description: "The LDAP users and groups assigned to the role.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getBigFixRole,
+				Transform:   transform.FromField("LDAPAssignments"),
+			},
+			{
+				Name:        "sites",
+				Description: "The sites the role grants access to, with the permission level on each site.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getBigFixRole,
+			},
+			{
+				Name:        "computer_assignments",
+				Description: "The computers the role grants access to, assigned by computer ID, computer group or retrieved property.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getBigFixRole,
+			},
 		},
 	}
 }
 
 func listBigFixRoles(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// When used as a parent hydrate by child tables, skip the list call if the role is already known
+	if idQual := d.EqualsQuals["role_id"]; idQual != nil {
+		d.StreamListItem(ctx, model.Role{ID: int(idQual.GetInt64Value())})
+		return nil, nil
+	}
+
 	// Create the service
 	client, err := NewService(ctx, d)
 	if err != nil {
@@ -128,21 +160,16 @@ func listBigFixRoles(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrate
 		return nil, err
 	}
 
-	// Get all roles
-	roles, err := client.Role.List(ctx)
-	if err != nil {
-		plugin.Logger(ctx).Error("bigfix_role.listBigFixRoles", "api_err", err)
-		return nil, err
-	}
-
-	// Stream the roles
-	for _, role := range roles {
+	// Get all roles, streaming each one as it is decoded
+	err = client.Role.ListFunc(ctx, func(role model.Role) bool {
 		d.StreamListItem(ctx, role)
 
 		// Context can be cancelled due to manual cancellation or the limit has been hit
-		if d.RowsRemaining(ctx) == 0 {
-			return nil, nil
-		}
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("bigfix_role.listBigFixRoles", "api_err", err)
+		return nil, err
 	}
 
 	return nil, nil
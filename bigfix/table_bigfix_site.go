@@ -2,6 +2,7 @@ package bigfix
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/turbot/steampipe-plugin-bigfix/api/model"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
@@ -25,20 +26,20 @@ func tableBigFixSite(_ context.Context) *plugin.Table {
 			},
 			Hydrate: getBigFixSite,
 			IgnoreConfig: &plugin.IgnoreConfig{
-				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"not found"}),
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]int{http.StatusNotFound}),
 			},
 		},
 		HydrateConfig: []plugin.HydrateConfig{
 			{
 				Func: getBigFixSitePermissions,
 				IgnoreConfig: &plugin.IgnoreConfig{
-					ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"not found"}),
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors([]int{http.StatusNotFound}),
 				},
 			},
 			{
 				Func: getBigFixSiteFiles,
 				IgnoreConfig: &plugin.IgnoreConfig{
-					ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"not found"}),
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors([]int{http.StatusNotFound}),
 				},
 			},
 		},
@@ -57,7 +58,7 @@ func tableBigFixSite(_ context.Context) *plugin.Table {
 			},
 			{
 				Name:        "type",
-				Description: "The type of the site (action, external, operator).",
+				Description: "The type of the site (action, external, operator, custom).",
 				Type:        proto.ColumnType_STRING,
 			},
 			{
@@ -119,19 +120,15 @@ func listBigFixSites(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrate
 		return nil, err
 	}
 
-	sites, err := client.Site.List()
-	if err != nil {
-		plugin.Logger(ctx).Error("bigfix_site.listBigFixSites", "api_err", err)
-		return nil, err
-	}
-
-	for _, site := range sites {
+	err = client.Site.ListFunc(ctx, func(site model.Site) bool {
 		d.StreamListItem(ctx, site)
 
 		// Context can be cancelled due to manual cancellation or the limit has been hit
-		if d.RowsRemaining(ctx) == 0 {
-			return nil, nil
-		}
+		return d.RowsRemaining(ctx) != 0
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("bigfix_site.listBigFixSites", "api_err", err)
+		return nil, err
 	}
 
 	return nil, nil
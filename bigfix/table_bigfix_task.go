@@ -2,12 +2,13 @@ package bigfix
 
 import (
 	"context"
-	"strings"
+	"net/http"
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
 
+	"github.com/turbot/steampipe-plugin-bigfix/api"
 	"github.com/turbot/steampipe-plugin-bigfix/api/model"
 )
 
@@ -16,8 +17,7 @@ func tableBigFixTask(ctx context.Context) *plugin.Table {
 		Name:        "bigfix_task",
 		Description: "BigFix Task contains multi-step deployment workflows and complex remediation procedures with coordinated fixlets and actions for sophisticated deployments.",
 		List: &plugin.ListConfig{
-			ParentHydrate: listBigFixSites,
-			Hydrate:       listBigFixTasks,
+			Hydrate: listBigFixTasks,
 			KeyColumns: []*plugin.KeyColumn{
 				{Name: "site_name", Require: plugin.Optional},
 				{Name: "site_type", Require: plugin.Optional},
@@ -31,14 +31,14 @@ func tableBigFixTask(ctx context.Context) *plugin.Table {
 			},
 			Hydrate: getBigFixTask,
 			IgnoreConfig: &plugin.IgnoreConfig{
-				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"not found"}),
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]int{http.StatusNotFound}),
 			},
 		},
 		HydrateConfig: []plugin.HydrateConfig{
 			{
 				Func: getBigFixTask,
 				IgnoreConfig: &plugin.IgnoreConfig{
-					ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"not found"}),
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors([]int{http.StatusNotFound}),
 				},
 			},
 		},
@@ -150,55 +150,62 @@ func tableBigFixTask(ctx context.Context) *plugin.Table {
 	}
 }
 
+// taskSiteDetailColumns are the columns populated by TaskService.ListDetails
+var taskSiteDetailColumns = map[string]bool{
+	"title":               true,
+	"category":            true,
+	"source_severity":     true,
+	"source_release_date": true,
+}
+
 func listBigFixTasks(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Create the service
 	client, err := NewService(ctx, d)
 	if err != nil {
 		plugin.Logger(ctx).Error("bigfix_task.listBigFixTasks", "service_creation_error", err)
 		return nil, err
 	}
 
-	// Get site from parent hydrate
-	site := h.Item.(model.Site)
-
-	// Check if optional key quals are provided to filter the results
-	var targetSiteName, targetSiteType string
-	if nameQual := d.EqualsQuals["site_name"]; nameQual != nil {
-		targetSiteName = nameQual.GetStringValue()
-	}
-	if typeQual := d.EqualsQuals["site_type"]; typeQual != nil {
-		targetSiteType = typeQual.GetStringValue()
-	}
-
-	// If optional quals are provided, only fetch if they match the current site
-	if targetSiteName != "" && targetSiteName != site.Name {
-		return nil, nil
-	}
-	if targetSiteType != "" && targetSiteType != site.Type {
-		return nil, nil
-	}
-
-	// Fetch tasks for the site
-	tasks, err := client.Task.List(ctx, site.Name, site.Type)
+	// List the tasks of the sites in parallel, up to max_site_concurrency sites at a time
+	err = forEachSite(ctx, d, h, client, func(ctx context.Context, site model.Site) error {
+		return listSiteTasks(ctx, d, client, site)
+	})
 	if err != nil {
-		// In the case of parent hydrate the Ignore config is not being honored.
-		if strings.Contains(strings.ToLower(err.Error()), "not found") {
-			return nil, nil
-		}
 		plugin.Logger(ctx).Error("bigfix_task.listBigFixTasks", "api_err", err)
 		return nil, err
 	}
 
-	// Return each task as a separate row
-	for _, task := range tasks {
-		d.StreamListItem(ctx, task)
+	return nil, nil
+}
 
-		// Context can be cancelled due to manual cancellation or the limit has been hit
-		if d.RowsRemaining(ctx) == 0 {
-			return nil, nil
+// listSiteTasks streams the tasks of a site
+func listSiteTasks(ctx context.Context, d *plugin.QueryData, client *api.Client, site model.Site) error {
+	// Fetch the selected details of all the tasks of the site at once instead of getting each one
+	var details map[int]model.Task
+	if useSiteDetails(d, taskSiteDetailColumns) {
+		var err error
+		details, err = client.Task.ListDetails(ctx, site.Name, site.Type)
+		if err != nil {
+			// The details are only an optimisation, each task is then got by its hydrate call instead
+			plugin.Logger(ctx).Warn("bigfix_task.listSiteTasks", "site_name", site.Name, "details_api_err", err)
+			details = nil
 		}
 	}
 
-	return nil, nil
+	// Fetch tasks for the site, streaming each one as it is decoded
+	return client.Task.ListFunc(ctx, site.Name, site.Type, func(task model.Task) bool {
+		if detail, ok := details[task.ID]; ok {
+			task.Title = detail.Title
+			task.Category = detail.Category
+			task.SourceSeverity = detail.SourceSeverity
+			task.SourceReleaseDate = detail.SourceReleaseDate
+			task.DetailsFetched = true
+		}
+		d.StreamListItem(ctx, task)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	})
 }
 
 func getBigFixTask(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
@@ -208,14 +215,21 @@ func getBigFixTask(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateDa
 		return nil, err
 	}
 
-	var siteName, siteType string
+	var siteName, siteType, lastModified string
 	var taskID int
 
 	if h.Item != nil {
-		site := h.Item.(model.Task)
-		siteName = site.SiteName
-		siteType = site.SiteType
-		taskID = site.ID
+		task := h.Item.(model.Task)
+
+		// The selected details were already fetched for the whole site by the list call
+		if task.DetailsFetched {
+			return &task, nil
+		}
+
+		siteName = task.SiteName
+		siteType = task.SiteType
+		taskID = task.ID
+		lastModified = task.LastModified
 	}
 
 	if nameQual := d.EqualsQuals["site_name"]; nameQual != nil {
@@ -233,7 +247,7 @@ func getBigFixTask(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateDa
 	}
 
 	// Fetch the specific task
-	task, err := client.Task.Get(ctx, siteName, siteType, taskID)
+	task, err := client.Task.GetCached(ctx, siteName, siteType, taskID, lastModified)
 	if err != nil {
 		plugin.Logger(ctx).Error("bigfix_task.getBigFixTask", "api_err", err)
 		return nil, err